@@ -0,0 +1,359 @@
+package aturicheck
+
+import (
+	"bytes"
+	"go/ast"
+	"go/constant"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/reiver/go-aturi"
+)
+
+const aturiPath string = "github.com/reiver/go-aturi"
+
+// Analyzer checks AT-URIs in Go code.
+var Analyzer = &analysis.Analyzer{
+	Name:     "aturi",
+	Doc:      "check for AT-URIs built by hand, and for constant AT-URIs that are invalid",
+	URL:      "https://pkg.go.dev/github.com/reiver/go-aturi/aturicheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// validators is the funcs of package aturi whose first parameter is an AT-URI,
+// and what each of them uses to validate it.
+var validators = map[string]func(string) error{
+	"Normalize":      aturi.Validate,
+	"Split":          aturi.Validate,
+	"Validate":       aturi.Validate,
+	"ValidateStrict": aturi.ValidateStrict,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	var nodes *inspector.Inspector = pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	var nodeFilter = []ast.Node{
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	nodes.WithStack(nodeFilter, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch node := node.(type) {
+		case *ast.BinaryExpr:
+			if isStringConcatenation(pass, node) && !isStringConcatenation(pass, parent(stack)) {
+				checkConcatenation(pass, node, file(stack))
+			}
+		case *ast.CallExpr:
+			checkCall(pass, node)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// parent returns the parent of the node at the top of the stack, skipping over parentheses.
+func parent(stack []ast.Node) ast.Node {
+	for index := len(stack) - 2; 0 <= index; index-- {
+		if _, isParen := stack[index].(*ast.ParenExpr); !isParen {
+			return stack[index]
+		}
+	}
+	return nil
+}
+
+func file(stack []ast.Node) *ast.File {
+	if 0 < len(stack) {
+		if file, ok := stack[0].(*ast.File); ok {
+			return file
+		}
+	}
+	return nil
+}
+
+func isStringConcatenation(pass *analysis.Pass, node ast.Node) bool {
+	expr, ok := node.(*ast.BinaryExpr)
+	if !ok || token.ADD != expr.Op {
+		return false
+	}
+
+	basic, ok := pass.TypesInfo.TypeOf(expr).Underlying().(*types.Basic)
+	return ok && 0 != basic.Info()&types.IsString
+}
+
+// piece is one operand of a string concatenation, after adjacent constant operands are merged.
+type piece struct {
+	text string   // the value, if the piece is constant
+	expr ast.Expr // the expression, if the piece is NOT constant
+}
+
+// flatten returns the operands of a string concatenation, in order, with adjacent constant operands merged.
+func flatten(pass *analysis.Pass, expr ast.Expr, pieces []piece) []piece {
+	expr = ast.Unparen(expr)
+
+	if isStringConcatenation(pass, expr) && nil == pass.TypesInfo.Types[expr].Value {
+		var binary *ast.BinaryExpr = expr.(*ast.BinaryExpr)
+		pieces = flatten(pass, binary.X, pieces)
+		return flatten(pass, binary.Y, pieces)
+	}
+
+	if value := pass.TypesInfo.Types[expr].Value; nil != value && constant.String == value.Kind() {
+		var text string = constant.StringVal(value)
+
+		if last := len(pieces) - 1; 0 <= last && nil == pieces[last].expr {
+			pieces[last].text += text
+			return pieces
+		}
+		return append(pieces, piece{text: text})
+	}
+
+	return append(pieces, piece{expr: expr})
+}
+
+func checkConcatenation(pass *analysis.Pass, expr *ast.BinaryExpr, file *ast.File) {
+	if nil != pass.TypesInfo.Types[expr].Value {
+		// The whole thing is a constant.
+		return
+	}
+
+	var pieces []piece = flatten(pass, expr, nil)
+	if len(pieces) < 2 || nil != pieces[0].expr || !hasPrefixFold(pieces[0].text, "at://") {
+		return
+	}
+
+	var diagnostic = analysis.Diagnostic{
+		Pos:     expr.Pos(),
+		End:     expr.End(),
+		Message: "AT-URI built by string concatenation; use aturi.Join instead",
+	}
+
+	if args, ok := joinArgs(pass, pieces); ok {
+		if name, imported := importName(file); imported {
+			var replacement string = name + ".Join(" + strings.Join(args, ", ") + ", \"\", \"\")"
+
+			// aturi.Join returns a string, so if the concatenation is of a named string type, the result is converted back to it.
+			if typ := pass.TypesInfo.TypeOf(expr); !isString(typ) {
+				typeName, ok := typeName(pass, file, typ)
+				if !ok {
+					pass.Report(diagnostic)
+					return
+				}
+				replacement = typeName + "(" + replacement + ")"
+			}
+
+			diagnostic.Message = "AT-URI built by string concatenation; use " + replacement + " instead"
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Replace with " + name + ".Join",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     expr.Pos(),
+					End:     expr.End(),
+					NewText: []byte(replacement),
+				}},
+			}}
+		}
+	}
+
+	pass.Report(diagnostic)
+}
+
+// joinArgs returns the 'authority', 'collection', and 'rkey' arguments for aturi.Join (as Go source code),
+// if the concatenation is simple enough to turn into a call to aturi.Join.
+//
+// It is simple enough if each component is either all constant or a single expression,
+// and there is no query or fragment.
+func joinArgs(pass *analysis.Pass, pieces []piece) ([]string, bool) {
+	var components [][]piece = [][]piece{nil}
+
+	var first piece = pieces[0]
+	first.text = first.text[len("at://"):]
+
+	for _, p := range append([]piece{first}, pieces[1:]...) {
+		if nil != p.expr {
+			var last int = len(components) - 1
+			components[last] = append(components[last], p)
+			continue
+		}
+
+		if strings.ContainsAny(p.text, "?#") {
+			return nil, false
+		}
+
+		for index, text := range strings.Split(p.text, "/") {
+			if 0 < index {
+				components = append(components, nil)
+			}
+			if "" != text {
+				var last int = len(components) - 1
+				components[last] = append(components[last], piece{text: text})
+			}
+		}
+	}
+
+	if 3 < len(components) {
+		return nil, false
+	}
+
+	var args []string = []string{`""`, `""`, `""`}
+	for index, component := range components {
+		if 1 != len(component) {
+			return nil, false
+		}
+
+		switch p := component[0]; {
+		case nil == p.expr:
+			args[index] = strconv.Quote(p.text)
+		default:
+			var buffer bytes.Buffer
+			if err := printer.Fprint(&buffer, pass.Fset, p.expr); nil != err {
+				return nil, false
+			}
+			args[index] = buffer.String()
+
+			// The parameters of aturi.Join are strings, so an operand of a named string type is converted.
+			if !isString(pass.TypesInfo.TypeOf(p.expr)) {
+				args[index] = "string(" + args[index] + ")"
+			}
+		}
+	}
+
+	return args, true
+}
+
+// isString returns whether the type is the predeclared type string (and not a named string type).
+func isString(typ types.Type) bool {
+	return types.Identical(typ, types.Typ[types.String])
+}
+
+// typeName returns the name of the type as Go source code in the file (such as "URI" or "example.URI"),
+// if the type can be named there.
+func typeName(pass *analysis.Pass, file *ast.File, typ types.Type) (string, bool) {
+	var ok bool = true
+
+	var name string = types.TypeString(typ, func(pkg *types.Package) string {
+		if pass.Pkg == pkg {
+			return ""
+		}
+
+		if nil != file {
+			for _, spec := range file.Imports {
+				path, err := strconv.Unquote(spec.Path.Value)
+				if nil != err || pkg.Path() != path {
+					continue
+				}
+
+				switch {
+				case nil == spec.Name:
+					return pkg.Name()
+				case "_" != spec.Name.Name && "." != spec.Name.Name:
+					return spec.Name.Name
+				}
+			}
+		}
+
+		ok = false
+		return pkg.Name()
+	})
+
+	return name, ok
+}
+
+// importName returns the name that the file imports package aturi as (if it does).
+func importName(file *ast.File) (string, bool) {
+	if nil == file {
+		return "", false
+	}
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if nil != err || aturiPath != path {
+			continue
+		}
+
+		switch {
+		case nil == spec.Name:
+			return "aturi", true
+		case "_" == spec.Name.Name, "." == spec.Name.Name:
+			return "", false
+		default:
+			return spec.Name.Name, true
+		}
+	}
+
+	return "", false
+}
+
+func checkCall(pass *analysis.Pass, call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || nil == fn.Pkg() || 0 == len(call.Args) {
+		return
+	}
+
+	var arg ast.Expr = call.Args[0]
+
+	value := pass.TypesInfo.Types[arg].Value
+	if nil == value || constant.String != value.Kind() {
+		return
+	}
+	var str string = constant.StringVal(value)
+
+	switch fn.Pkg().Path() {
+	case "fmt":
+		if "Sprintf" == fn.Name() && hasPrefixFold(str, "at://") && strings.Contains(str, "%") {
+			pass.Reportf(call.Pos(), "AT-URI built with fmt.Sprintf; use aturi.Join instead")
+		}
+	case aturiPath:
+		if nil != fn.Type().(*types.Signature).Recv() {
+			return
+		}
+
+		validate, found := validators[fn.Name()]
+		if !found {
+			return
+		}
+
+		err := validate(str)
+		if nil == err {
+			return
+		}
+
+		var diagnostic = analysis.Diagnostic{
+			Pos:     arg.Pos(),
+			End:     arg.End(),
+			Message: "aturi." + fn.Name() + " always fails on this constant: " + err.Error(),
+		}
+
+		if _, isLiteral := ast.Unparen(arg).(*ast.BasicLit); isLiteral {
+			if repaired, _, err := aturi.Repair(str); nil == err && nil == validate(repaired) {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+					Message: "Replace with " + strconv.Quote(repaired),
+					TextEdits: []analysis.TextEdit{{
+						Pos:     arg.Pos(),
+						End:     arg.End(),
+						NewText: []byte(strconv.Quote(repaired)),
+					}},
+				}}
+			}
+		}
+
+		pass.Report(diagnostic)
+	}
+}
+
+// hasPrefixFold is like strings.HasPrefix, but ASCII case-insensitive.
+func hasPrefixFold(s string, prefix string) bool {
+	return len(prefix) <= len(s) && strings.EqualFold(s[:len(prefix)], prefix)
+}
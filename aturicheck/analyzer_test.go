@@ -0,0 +1,13 @@
+package aturicheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/reiver/go-aturi/aturicheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), aturicheck.Analyzer, "a")
+}
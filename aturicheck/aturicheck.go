@@ -0,0 +1,16 @@
+// Package aturicheck has a go vet analyzer (a golang.org/x/tools/go/analysis Analyzer) that checks AT-URIs in Go code.
+//
+// It reports:
+//
+//	• AT-URIs that are built by string concatenation (such as "at://" + did + "/" + collection + "/" + rkey),
+//	  and suggests a call to aturi.Join instead,
+//	• AT-URIs that are built with fmt.Sprintf (such as fmt.Sprintf("at://%s/%s/%s", did, collection, rkey)),
+//	• constant AT-URIs passed to aturi.Split, aturi.Validate, aturi.ValidateStrict, or aturi.Normalize that would always fail,
+//	  and (if [aturi.Repair] can fix it) suggests the repaired AT-URI instead.
+//
+// To run it with go vet:
+//
+//	go install github.com/reiver/go-aturi/cmd/aturicheck@latest
+//	
+//	go vet -vettool=$(which aturicheck) ./...
+package aturicheck
@@ -0,0 +1,57 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/reiver/go-aturi"
+)
+
+const post string = "app.bsky.feed.post"
+
+func concatenation(did string, collection string, rkey string) {
+	_ = "at://" + did + "/" + collection + "/" + rkey // want `AT-URI built by string concatenation; use aturi.Join\(did, collection, rkey, "", ""\) instead`
+	_ = "at://" + did + "/app.bsky.feed.post/" + rkey // want `AT-URI built by string concatenation; use aturi.Join\(did, "app.bsky.feed.post", rkey, "", ""\) instead`
+	_ = "at://" + did + "/" + post + "/" + rkey       // want `AT-URI built by string concatenation; use aturi.Join\(did, "app.bsky.feed.post", rkey, "", ""\) instead`
+	_ = "AT://" + did                                 // want `AT-URI built by string concatenation; use aturi.Join\(did, "", "", "", ""\) instead`
+	_ = ("at://" + did) + ("/" + collection)          // want `AT-URI built by string concatenation; use aturi.Join\(did, collection, "", "", ""\) instead`
+
+	_ = "at://did:plc:" + rkey + "/" + collection     // want `AT-URI built by string concatenation; use aturi.Join instead`
+	_ = "at://" + did + "?" + rkey                    // want `AT-URI built by string concatenation; use aturi.Join instead`
+	_ = "at://" + did + "/" + collection + "/" + rkey + "/" + rkey // want `AT-URI built by string concatenation; use aturi.Join instead`
+
+	_ = "at://" + "example.com"
+	_ = "https://" + did
+	_ = did + "at://"
+}
+
+type Text string
+
+func namedTypes(did Text, collection Text, rkey Text) {
+	_ = "at://" + did + "/" + collection + "/" + rkey // want `AT-URI built by string concatenation; use Text\(aturi.Join\(string\(did\), string\(collection\), string\(rkey\), "", ""\)\) instead`
+	_ = "at://" + did + "/app.bsky.feed.post"         // want `AT-URI built by string concatenation; use Text\(aturi.Join\(string\(did\), "app.bsky.feed.post", "", "", ""\)\) instead`
+	_ = "at://" + string(did) + "/app.bsky.feed.post" // want `AT-URI built by string concatenation; use aturi.Join\(string\(did\), "app.bsky.feed.post", "", "", ""\) instead`
+
+	var uri Text = "at://" + did // want `AT-URI built by string concatenation; use Text\(aturi.Join\(string\(did\), "", "", "", ""\)\) instead`
+	_ = uri
+}
+
+func sprintf(did string, collection string, rkey string) {
+	_ = fmt.Sprintf("at://%s/%s/%s", did, collection, rkey) // want `AT-URI built with fmt.Sprintf; use aturi.Join instead`
+	_ = fmt.Sprintf("https://%s", did)
+	_ = fmt.Sprintf("at://example.com")
+}
+
+func constants(uri string) {
+	aturi.Split("at:/example.com/app.bsky.feed.post")          // want `aturi.Split always fails on this constant: aturi: URI "at:/example.com/app.bsky.feed.post" is not an at-uri because it does not begin with "at://"`
+	aturi.Validate("<at://example.com/app.bsky.feed.post>")    // want `aturi.Validate always fails on this constant`
+	aturi.Normalize("at://example.com/app..bsky")              // want `aturi.Normalize always fails on this constant`
+	aturi.ValidateStrict("at://example.com/app.bsky.feed.post/") // want `aturi.ValidateStrict always fails on this constant`
+
+	const bad string = "https://example.com/"
+	aturi.Validate(bad) // want `aturi.Validate always fails on this constant`
+
+	aturi.Validate("at://example.com/app.bsky.feed.post")
+	aturi.ValidateStrict("at://example.com/app.bsky.feed.post/3jui7kd54zh2y")
+	aturi.Validate(uri)
+	aturi.Parser{}.Validate("https://example.com/")
+}
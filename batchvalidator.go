@@ -0,0 +1,189 @@
+package aturi
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/reiver/go-erorr"
+)
+
+// BatchValidator validates many AT-URIs, one per line, such as from a database dump.
+//
+// The lines are validated concurrently, but failures are reported in the same order as the lines.
+//
+// For example:
+//
+//	var validator aturi.BatchValidator
+//	
+//	summary, err := validator.Validate(os.Stdin, func(failure aturi.BatchFailure) {
+//		fmt.Printf("line %d: %s\n", failure.Line, failure.Err)
+//	})
+//	if nil != err {
+//		return err
+//	}
+//	
+//	fmt.Printf("%d of %d lines are invalid\n", summary.Invalid, summary.Lines)
+type BatchValidator struct {
+	// Workers is the number of goroutines that validate lines.
+	// If Workers is 0 (or less), runtime.GOMAXPROCS(0) goroutines are used.
+	Workers int
+
+	// Strict says whether to use [ValidateStrict] rather than [Validate].
+	Strict bool
+}
+
+// BatchFailure is a line that a [BatchValidator] found to be invalid.
+type BatchFailure struct {
+	Line  int    // the line number (the first line is line 1)
+	Input string // the line (without its line ending)
+	Err   error  // the error from Validate or ValidateStrict; usually an [*Error]
+}
+
+// Kind returns the kind of the error, or "" if the error is not an [*Error].
+func (receiver BatchFailure) Kind() ErrorKind {
+	var aturiError *Error
+	if errors.As(receiver.Err, &aturiError) {
+		return aturiError.Kind
+	}
+	return ""
+}
+
+// BatchSummary has the counts from a [BatchValidator].
+type BatchSummary struct {
+	Lines   int               // the number of lines read
+	Valid   int               // the number of valid lines
+	Invalid int               // the number of invalid lines
+	Kinds   map[ErrorKind]int // the number of invalid lines for each kind of error
+}
+
+// batchSize is the number of lines that are handed to a worker at a time.
+const batchSize int = 256
+
+type batch struct {
+	firstLine int
+	lines     []string
+	failures  []BatchFailure
+	done      chan struct{}
+}
+
+// Validate reads lines from 'reader' and validates each one.
+// fn (if not nil) is called for each invalid line, in line order, from a single goroutine.
+//
+// A "\r" at the end of a line is ignored, so that files with "\r\n" line endings work.
+//
+// Validate returns an error only if reading from 'reader' fails.
+// Invalid lines are NOT returned as an error.
+func (receiver BatchValidator) Validate(reader io.Reader, fn func(BatchFailure)) (BatchSummary, error) {
+	var summary = BatchSummary{
+		Kinds: map[ErrorKind]int{},
+	}
+
+	if nil == reader {
+		return summary, errNilReader
+	}
+
+	var workers int = receiver.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var validate func(string) error = Validate
+	if receiver.Strict {
+		validate = ValidateStrict
+	}
+
+	// 'pending' is how the order of the lines is kept:
+	// batches go into it in line order, and are taken out of it in line order (after they are done).
+	// Because both channels are bounded, so is the amount of memory used.
+	var jobs    chan *batch = make(chan *batch, workers)
+	var pending chan *batch = make(chan *batch, workers*2)
+
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for job := range jobs {
+				for index, line := range job.lines {
+					if err := validate(line); nil != err {
+						job.failures = append(job.failures, BatchFailure{
+							Line:  job.firstLine + index,
+							Input: line,
+							Err:   err,
+						})
+					}
+				}
+				close(job.done)
+			}
+		}()
+	}
+
+	var readErr error
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+
+		var scanner *bufio.Scanner = bufio.NewScanner(reader)
+		{
+			// Big enough that a too-long AT-URI gets reported as too long, rather than making the scanner fail.
+			const max int = 1024 * 1024
+
+			scanner.Buffer(make([]byte, 0, 64*1024), max)
+		}
+
+		var lineNumber int = 1
+		var job *batch
+
+		for scanner.Scan() {
+			if nil == job {
+				job = &batch{
+					firstLine: lineNumber,
+					lines:     make([]string, 0, batchSize),
+					done:      make(chan struct{}),
+				}
+			}
+
+			job.lines = append(job.lines, strings.TrimSuffix(scanner.Text(), "\r"))
+			lineNumber++
+
+			if batchSize <= len(job.lines) {
+				pending <- job
+				jobs <- job
+				job = nil
+			}
+		}
+		if nil != job {
+			pending <- job
+			jobs <- job
+		}
+
+		if err := scanner.Err(); nil != err {
+			readErr = erorr.Errorf("aturi: problem reading line %d: %w", lineNumber, err)
+		}
+	}()
+
+	for job := range pending {
+		<-job.done
+
+		summary.Lines += len(job.lines)
+		summary.Invalid += len(job.failures)
+		summary.Valid += len(job.lines) - len(job.failures)
+
+		for _, failure := range job.failures {
+			summary.Kinds[failure.Kind()]++
+
+			if nil != fn {
+				fn(failure)
+			}
+		}
+	}
+
+	waitGroup.Wait()
+
+	return summary, readErr
+}
@@ -0,0 +1,124 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"fmt"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestBatchValidator(t *testing.T) {
+
+	var lines []string
+	var expectedFailures []int
+	{
+		// enough lines that there are many batches, and more batches than workers.
+		const numLines int = 10000
+
+		for lineNumber := 1; lineNumber <= numLines; lineNumber++ {
+			switch {
+			case 0 == lineNumber % 7:
+				lines = append(lines, fmt.Sprintf("https://example.com/%d", lineNumber))
+				expectedFailures = append(expectedFailures, lineNumber)
+			case 0 == lineNumber % 11:
+				lines = append(lines, fmt.Sprintf("at://example.com/foorBar/%d", lineNumber))
+				expectedFailures = append(expectedFailures, lineNumber)
+			default:
+				lines = append(lines, fmt.Sprintf("at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/%d", lineNumber))
+			}
+		}
+	}
+
+	for _, workers := range []int{0, 1, 3, 16} {
+
+		var input string = strings.Join(lines, "\r\n") + "\r\n"
+
+		var actualFailures []int
+
+		validator := aturi.BatchValidator{
+			Workers: workers,
+		}
+
+		summary, err := validator.Validate(strings.NewReader(input), func(failure aturi.BatchFailure) {
+			actualFailures = append(actualFailures, failure.Line)
+
+			if expected, actual := lines[failure.Line-1], failure.Input; expected != actual {
+				t.Errorf("For workers=%d, the actual 'input' for line %d is not what was expected.", workers, failure.Line)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+			}
+		})
+		if nil != err {
+			t.Errorf("For workers=%d, did not expect an error but actually got one.", workers)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		{
+			expected := fmt.Sprint(expectedFailures)
+			actual   := fmt.Sprint(actualFailures)
+
+			if expected != actual {
+				t.Errorf("For workers=%d, the actual failures are not what was expected (or are not in line order).", workers)
+				continue
+			}
+		}
+
+		{
+			var expectedSchemeFailures int = len(lines) / 7
+			var expectedCollectionFailures int = len(expectedFailures) - expectedSchemeFailures
+
+			expected := fmt.Sprintf("lines=%d valid=%d invalid=%d scheme=%d collection=%d", len(lines), len(lines)-len(expectedFailures), len(expectedFailures), expectedSchemeFailures, expectedCollectionFailures)
+			actual   := fmt.Sprintf("lines=%d valid=%d invalid=%d scheme=%d collection=%d", summary.Lines, summary.Valid, summary.Invalid, summary.Kinds[aturi.ErrorKindScheme], summary.Kinds[aturi.ErrorKindCollection])
+
+			if expected != actual {
+				t.Errorf("For workers=%d, the actual summary is not what was expected.", workers)
+				t.Logf("EXPECTED: %s", expected)
+				t.Logf("ACTUAL:   %s", actual)
+				continue
+			}
+		}
+	}
+}
+
+func TestBatchValidator_strict(t *testing.T) {
+
+	const input string =
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y" + "\n" +
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y/" + "\n" +
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1" + "\n" +
+		"" + "\n"
+
+	var validator = aturi.BatchValidator{
+		Strict: true,
+	}
+
+	var actual []string
+	summary, err := validator.Validate(strings.NewReader(input), func(failure aturi.BatchFailure) {
+		actual = append(actual, fmt.Sprintf("%d:%s", failure.Line, failure.Kind()))
+	})
+	if nil != err {
+		t.Errorf("Did not expect an error but actually got one.")
+		t.Logf("ERROR: (%T) %s", err, err)
+		return
+	}
+
+	{
+		expected := "[2:path 3:query 4:empty]"
+
+		if expected != fmt.Sprint(actual) {
+			t.Errorf("The actual failures are not what was expected.")
+			t.Logf("EXPECTED: %s", expected)
+			t.Logf("ACTUAL:   %s", actual)
+			return
+		}
+	}
+
+	if 4 != summary.Lines || 1 != summary.Valid || 3 != summary.Invalid {
+		t.Errorf("The actual summary is not what was expected.")
+		t.Logf("ACTUAL: %#v", summary)
+		return
+	}
+}
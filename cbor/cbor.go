@@ -0,0 +1,19 @@
+// Package cbor marshals AT-protocol types from [github.com/reiver/go-aturi] to (and unmarshals them from) DAG-CBOR,
+// as used in AT-protocol repositories and the firehose:
+// [StrongRef] (for a strong-ref in a record) and [CommitOp] (for an operation in a firehose "#commit" event).
+//
+// It is a separate package so that importing go-aturi does not also import a CBOR library.
+//
+// For example:
+//
+//	var like struct {
+//		Subject cbor.StrongRef `cbor:"subject"`
+//	}
+//	
+//	err := fxcbor.Unmarshal(data, &like)
+//	if nil != err {
+//		return err
+//	}
+//	
+//	var ref aturi.StrongRef = like.Subject.StrongRef
+package cbor
@@ -0,0 +1,102 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/base32"
+
+	fxcbor "github.com/fxamacker/cbor/v2"
+	"github.com/reiver/go-erorr"
+
+	"github.com/reiver/go-aturi"
+)
+
+// CommitOp is an [aturi.CommitOp] that can be unmarshaled from the DAG-CBOR of the "com.atproto.sync.subscribeRepos" firehose,
+// where the CID is a CID link (CBOR tag 42).
+//
+// For example:
+//
+//	var body struct {
+//		Repo string          `cbor:"repo"`
+//		Ops  []cbor.CommitOp `cbor:"ops"`
+//	}
+//	
+//	err := decoder.Decode(&body)
+//	if nil != err {
+//		return err
+//	}
+//	
+//	var ops []aturi.CommitOp
+//	for _, op := range body.Ops {
+//		ops = append(ops, op.CommitOp)
+//	}
+//	
+//	err = aturi.CommitEvents(body.Repo, ops, fn)
+type CommitOp struct {
+	aturi.CommitOp
+}
+
+var _ fxcbor.Unmarshaler = &CommitOp{}
+
+// base32Lower is the multibase "base32" encoding (multibase prefix 'b'): RFC 4648 base32, lower-case, no padding.
+var base32Lower = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// UnmarshalCBOR makes CommitOp fit the cbor.Unmarshaler interface.
+//
+// UnmarshalCBOR does NOT validate the CommitOp; [aturi.CommitEvents] does.
+func (receiver *CommitOp) UnmarshalCBOR(data []byte) error {
+	if nil == receiver {
+		return errNilReceiver
+	}
+
+	var fields struct {
+		Action string            `cbor:"action"`
+		Path   string            `cbor:"path"`
+		CID    fxcbor.RawMessage `cbor:"cid"`
+	}
+	if err := fxcbor.Unmarshal(data, &fields); nil != err {
+		return erorr.Errorf("cbor: problem unmarshaling commit operation from CBOR: %w", err)
+	}
+
+	var op = aturi.CommitOp{
+		Action: fields.Action,
+		Path:   fields.Path,
+	}
+
+	// A "delete" has a CBOR null (0xf6) as its CID.
+	if 0 < len(fields.CID) && !bytes.Equal(fields.CID, cborNull) {
+		var link fxcbor.Tag
+		if err := fxcbor.Unmarshal(fields.CID, &link); nil != err {
+			return erorr.Errorf("cbor: commit operation on %q has a CID that is not a CID link: %w", fields.Path, err)
+		}
+
+		cid, err := cidFromLink(link)
+		if nil != err {
+			return erorr.Errorf("cbor: commit operation on %q has a bad CID: %w", fields.Path, err)
+		}
+		op.CID = cid
+	}
+
+	receiver.CommitOp = op
+	return nil
+}
+
+// cidFromLink returns the base32 string form of a DAG-CBOR CID link.
+//
+// A CID link is CBOR tag 42 around a byte string of a 0x00 (the "identity" multibase prefix) followed by the binary CID.
+func cidFromLink(tag fxcbor.Tag) (string, error) {
+	const linkTag uint64 = 42
+
+	if linkTag != tag.Number {
+		return "", erorr.Errorf("cbor: CID link has CBOR tag %d rather than tag %d", tag.Number, linkTag)
+	}
+
+	content, ok := tag.Content.([]byte)
+	if !ok {
+		return "", erorr.Errorf("cbor: CID link has content of type %T rather than a byte string", tag.Content)
+	}
+	if len(content) < 2 || 0x00 != content[0] {
+		return "", erorr.Error("cbor: CID link does not begin with a 0x00 byte followed by a CID")
+	}
+
+	return "b" + base32Lower.EncodeToString(content[1:]), nil
+}
@@ -0,0 +1,170 @@
+package cbor_test
+
+import (
+	"testing"
+
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	fxcbor "github.com/fxamacker/cbor/v2"
+
+	"github.com/reiver/go-aturi"
+	"github.com/reiver/go-aturi/cbor"
+)
+
+// readCommitFrame reads a "#commit" firehose frame (a header followed by a body) from "testdata/firehose".
+//
+// The frames there are synthetic (built by hand to match the frame layout), NOT captured from a relay
+// (see "testdata/firehose/README.md").
+func readCommitFrame(t *testing.T, name string) (repo string, ops []aturi.CommitOp) {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/firehose/" + name)
+	if nil != err {
+		t.Fatalf("Did not expect an error when reading %q but actually got one: (%T) %s", name, err, err)
+	}
+
+	var decoder *fxcbor.Decoder = fxcbor.NewDecoder(bytes.NewReader(data))
+
+	var header struct {
+		Op   int    `cbor:"op"`
+		Type string `cbor:"t"`
+	}
+	if err := decoder.Decode(&header); nil != err {
+		t.Fatalf("Did not expect an error when decoding the header of %q but actually got one: (%T) %s", name, err, err)
+	}
+	if 1 != header.Op || "#commit" != header.Type {
+		t.Fatalf("Expected %q to be a %q frame, but it actually has op=%d t=%q", name, "#commit", header.Op, header.Type)
+	}
+
+	var body struct {
+		Repo string          `cbor:"repo"`
+		Ops  []cbor.CommitOp `cbor:"ops"`
+	}
+	if err := decoder.Decode(&body); nil != err {
+		t.Fatalf("Did not expect an error when decoding the body of %q but actually got one: (%T) %s", name, err, err)
+	}
+
+	for _, op := range body.Ops {
+		ops = append(ops, op.CommitOp)
+	}
+	return body.Repo, ops
+}
+
+func TestCommitEvents(t *testing.T) {
+
+	repo, ops := readCommitFrame(t, "commit.cbor")
+
+	var actual []string
+	err := aturi.CommitEvents(repo, ops, func(event aturi.CommitEvent) bool {
+		actual = append(actual, fmt.Sprintf("%s %s %s/%s/%s %s", event.Action, event.URI, event.Repo, event.Collection, event.RKey, event.CID))
+		return true
+	})
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	expected := []string{
+		"create at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3l3qo2vuowo2b did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3l3qo2vuowo2b bafyreidsemiehpaya7tpoqfsgxvxkepmwmzfljvdovbvmmiznxuks5injm",
+		"create at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like/3l3qo2vusxu2b did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like/3l3qo2vusxu2b bafyreigkwjseqkf4yhzcayjp3zkzqzg2frn7brxoxxfnxnfezcgj7tzze4",
+		"update at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self bafyreiazadvlnqbija6xcjszt3tpkdpa2j4qpnogl6uqkjcybnfq7gcswa",
+		"delete at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/3jui7kd54zh2y did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/3jui7kd54zh2y ",
+	}
+
+	if fmt.Sprintf("%q", expected) != fmt.Sprintf("%q", actual) {
+		t.Errorf("The actual events are not what was expected.")
+		for index := range expected {
+			t.Logf("EXPECTED[%d]: %q", index, expected[index])
+		}
+		for index := range actual {
+			t.Logf("ACTUAL[%d]:   %q", index, actual[index])
+		}
+	}
+}
+
+func TestCommitEvents_stop(t *testing.T) {
+
+	repo, ops := readCommitFrame(t, "commit.cbor")
+
+	var count int
+	err := aturi.CommitEvents(repo, ops, func(aturi.CommitEvent) bool {
+		count++
+		return false
+	})
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	if expected, actual := 1, count; expected != actual {
+		t.Errorf("The actual number of events is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+}
+
+func TestCommitEvents_badPath(t *testing.T) {
+
+	repo, ops := readCommitFrame(t, "commit-bad-path.cbor")
+
+	var count int
+	err := aturi.CommitEvents(repo, ops, func(aturi.CommitEvent) bool {
+		count++
+		return true
+	})
+	if nil == err {
+		t.Fatalf("Expected an error but did not actually get one.")
+	}
+
+	if expected, actual := 1, count; expected != actual {
+		t.Errorf("The actual number of events before the error is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+
+	var aturiError *aturi.Error
+	if !errors.As(err, &aturiError) {
+		t.Fatalf("Expected the error to be an %T but it actually is not: (%T) %s", aturiError, err, err)
+	}
+
+	if expected, actual := aturi.ErrorKindPath, aturiError.Kind; expected != actual {
+		t.Errorf("The actual error kind is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+}
+
+func TestCommitOp_UnmarshalCBOR_fail(t *testing.T) {
+
+	var binaryCID []byte = append([]byte{0x00}, 0x01, 0x71, 0x12, 0x20)
+	binaryCID = append(binaryCID, make([]byte, 32)...)
+
+	tests := []any{
+		fxcbor.Tag{Number: 41, Content: binaryCID},                // not tag 42
+		fxcbor.Tag{Number: 42, Content: "not a byte string"},
+		fxcbor.Tag{Number: 42, Content: binaryCID[1:]},            // no 0x00 at the beginning
+		fxcbor.Tag{Number: 42, Content: []byte{0x00}},             // no CID after the 0x00
+		"bafyreidsemiehpaya7tpoqfsgxvxkepmwmzfljvdovbvmmiznxuks5injm", // a string rather than a CID link
+	}
+
+	for testNumber, test := range tests {
+
+		data, err := fxcbor.Marshal(map[string]any{
+			"action": "create",
+			"path":   "app.bsky.feed.post/3l3qo2vuowo2b",
+			"cid":    test,
+		})
+		if nil != err {
+			t.Fatalf("For test #%d, did not expect an error when marshaling but actually got one: (%T) %s", testNumber, err, err)
+		}
+
+		var op cbor.CommitOp
+		if err := fxcbor.Unmarshal(data, &op); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("CID: %#v", test)
+			t.Logf("OP:  %#v", op)
+			continue
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package cbor
+
+import (
+	"github.com/reiver/go-erorr"
+)
+
+const (
+	errNilReceiver = erorr.Error("cbor: nil receiver")
+)
@@ -0,0 +1,80 @@
+package cbor
+
+import (
+	"bytes"
+
+	fxcbor "github.com/fxamacker/cbor/v2"
+	"github.com/reiver/go-erorr"
+
+	"github.com/reiver/go-aturi"
+)
+
+// StrongRef is an [aturi.StrongRef] that can be marshaled to (and unmarshaled from) DAG-CBOR,
+// as a map with a "uri" and a "cid".
+//
+// Marshaling and unmarshaling both return an error if the StrongRef is not valid (see [aturi.StrongRef.Validate]),
+// including the zero StrongRef.
+// For an optional field, use a *StrongRef with "omitempty" (see [aturi.StrongRef]).
+type StrongRef struct {
+	aturi.StrongRef
+}
+
+var (
+	_ fxcbor.Marshaler   = StrongRef{}
+	_ fxcbor.Unmarshaler = &StrongRef{}
+)
+
+// cborNull is the CBOR encoding of null.
+var cborNull = []byte{0xf6}
+
+// strongRefFields is what a StrongRef looks like when it is marshaled.
+type strongRefFields struct {
+	URI string `cbor:"uri"`
+	CID string `cbor:"cid"`
+}
+
+// encMode encodes CBOR the way DAG-CBOR requires (such as map keys sorted by length first).
+var encMode fxcbor.EncMode = func() fxcbor.EncMode {
+	encMode, err := fxcbor.CanonicalEncOptions().EncMode()
+	if nil != err {
+		panic(err)
+	}
+	return encMode
+}()
+
+// MarshalCBOR makes StrongRef fit the cbor.Marshaler interface.
+func (receiver StrongRef) MarshalCBOR() ([]byte, error) {
+	if err := receiver.Validate(); nil != err {
+		return nil, err
+	}
+
+	return encMode.Marshal(strongRefFields(receiver.StrongRef))
+}
+
+// UnmarshalCBOR makes StrongRef fit the cbor.Unmarshaler interface.
+//
+// Any other fields (such as "$type") are ignored.
+// A CBOR null is unmarshaled as the zero StrongRef.
+func (receiver *StrongRef) UnmarshalCBOR(data []byte) error {
+	if nil == receiver {
+		return errNilReceiver
+	}
+
+	if bytes.Equal(data, cborNull) {
+		*receiver = StrongRef{}
+		return nil
+	}
+
+	var fields strongRefFields
+	if err := fxcbor.Unmarshal(data, &fields); nil != err {
+		return erorr.Errorf("cbor: problem unmarshaling strong-ref from CBOR: %w", err)
+	}
+
+	var ref aturi.StrongRef = aturi.StrongRef(fields)
+	if err := ref.Validate(); nil != err {
+		return err
+	}
+
+	receiver.StrongRef = ref
+	return nil
+}
@@ -0,0 +1,112 @@
+package cbor_test
+
+import (
+	"testing"
+
+	"bytes"
+
+	fxcbor "github.com/fxamacker/cbor/v2"
+
+	"github.com/reiver/go-aturi"
+	"github.com/reiver/go-aturi/cbor"
+)
+
+const (
+	testStrongRefURI string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+	testStrongRefCID string = "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a"
+)
+
+func TestStrongRef(t *testing.T) {
+
+	var ref = cbor.StrongRef{aturi.StrongRef{URI: testStrongRefURI, CID: testStrongRefCID}}
+
+	data, err := fxcbor.Marshal(ref)
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	{
+		// DAG-CBOR sorts map keys by length and then by bytes, so "cid" comes before "uri".
+		var expected []byte
+		expected = append(expected, 0xa2)
+		expected = append(expected, 0x63, 'c', 'i', 'd')
+		expected = append(expected, 0x78, byte(len(testStrongRefCID)))
+		expected = append(expected, testStrongRefCID...)
+		expected = append(expected, 0x63, 'u', 'r', 'i')
+		expected = append(expected, 0x78, byte(len(testStrongRefURI)))
+		expected = append(expected, testStrongRefURI...)
+
+		if actual := data; !bytes.Equal(expected, actual) {
+			t.Errorf("The actual CBOR is not what was expected.")
+			t.Logf("EXPECTED: %x", expected)
+			t.Logf("ACTUAL:   %x", actual)
+		}
+	}
+
+	{
+		var actual cbor.StrongRef
+
+		if err := fxcbor.Unmarshal(data, &actual); nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+
+		if expected := ref; expected != actual {
+			t.Errorf("The actual unmarshaled strong-ref is not what was expected.")
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+		}
+	}
+
+	if _, err := fxcbor.Marshal(cbor.StrongRef{aturi.StrongRef{URI: "at://example.com", CID: testStrongRefCID}}); nil == err {
+		t.Errorf("Expected an error when marshaling an invalid strong-ref but did not actually get one.")
+	}
+}
+
+func TestStrongRef_zero(t *testing.T) {
+
+	if _, err := fxcbor.Marshal(struct{
+		Subject cbor.StrongRef `cbor:"subject"`
+	}{}); nil == err {
+		t.Errorf("Expected an error when marshaling the zero strong-ref but did not actually get one.")
+	}
+
+	{
+		data, err := fxcbor.Marshal(struct{
+			Reply *cbor.StrongRef `cbor:"reply,omitempty"`
+		}{})
+		if nil != err {
+			t.Fatalf("Did not expect an error when marshaling a nil *StrongRef but actually got one: (%T) %s", err, err)
+		}
+
+		// An empty map.
+		if expected, actual := []byte{0xa0}, data; !bytes.Equal(expected, actual) {
+			t.Errorf("The actual CBOR of a nil *StrongRef with omitempty is not what was expected.")
+			t.Logf("EXPECTED: %x", expected)
+			t.Logf("ACTUAL:   %x", actual)
+		}
+	}
+
+	{
+		// A map with 1 entry, "subject", whose value is null.
+		var data []byte
+		data = append(data, 0xa1)
+		data = append(data, 0x67, 's', 'u', 'b', 'j', 'e', 'c', 't')
+		data = append(data, 0xf6)
+
+		var actual = struct{
+			Subject cbor.StrongRef `cbor:"subject"`
+		}{
+			Subject: cbor.StrongRef{aturi.StrongRef{URI: testStrongRefURI, CID: testStrongRefCID}},
+		}
+
+		if err := fxcbor.Unmarshal(data, &actual); nil != err {
+			t.Fatalf("Did not expect an error when unmarshaling a null strong-ref but actually got one: (%T) %s", err, err)
+		}
+
+		if expected := (cbor.StrongRef{}); expected != actual.Subject {
+			t.Errorf("The actual unmarshaled null strong-ref is not what was expected.")
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual.Subject)
+		}
+	}
+}
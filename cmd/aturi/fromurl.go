@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/reiver/go-aturi"
+)
+
+func fromURL(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flagSet, format := newFlagSet("from-url", stderr)
+	if err := flagSet.Parse(args); nil != err {
+		return exitUsage
+	}
+	if err := checkFormat(*format); nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	err := eachInput(flagSet.Args(), stdin, func(input string) error {
+		uri, err := aturi.FromBskyAppURL(input)
+		writeResult(stdout, *format, input, uri, err)
+		return err
+	})
+	if nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/reiver/go-aturi"
+)
+
+func lint(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flagSet, format := newFlagSet("lint", stderr)
+	if err := flagSet.Parse(args); nil != err {
+		return exitUsage
+	}
+	if err := checkFormat(*format); nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	var status int = exitOK
+
+	err := eachInput(flagSet.Args(), stdin, func(input string) error {
+		var diagnostics []aturi.Diagnostic = aturi.Lint(input)
+
+		for _, diagnostic := range diagnostics {
+			if aturi.SeverityError == diagnostic.Severity {
+				status = exitInvalid
+			}
+		}
+
+		switch *format {
+		case formatJSON:
+			type jsonDiagnostic struct {
+				Begin      int    `json:"begin"`
+				End        int    `json:"end"`
+				Severity   string `json:"severity"`
+				Kind       string `json:"kind,omitempty"`
+				Message    string `json:"message"`
+				Suggestion string `json:"suggestion,omitempty"`
+			}
+
+			var result = struct{
+				Input       string           `json:"input"`
+				Diagnostics []jsonDiagnostic `json:"diagnostics"`
+			}{
+				Input:       input,
+				Diagnostics: []jsonDiagnostic{},
+			}
+			for _, diagnostic := range diagnostics {
+				result.Diagnostics = append(result.Diagnostics, jsonDiagnostic{
+					Begin:      diagnostic.Begin,
+					End:        diagnostic.End,
+					Severity:   string(diagnostic.Severity),
+					Kind:       string(diagnostic.Kind),
+					Message:    diagnostic.Message,
+					Suggestion: diagnostic.Suggestion,
+				})
+			}
+			writeJSON(stdout, result)
+		default:
+			fmt.Fprint(stderr, aturi.FormatDiagnostics(input, diagnostics))
+		}
+		return nil
+	})
+	if nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	return status
+}
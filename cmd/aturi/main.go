@@ -0,0 +1,75 @@
+// Command aturi parses, validates, normalizes, and converts AT-URIs.
+//
+// Usage:
+//
+//	aturi parse     [-format text|json] <uri>
+//	aturi validate  [-format text|json] [-strict]       < uris.txt
+//	aturi normalize [-format text|json] [<uri> ...]
+//	aturi from-url  [-format text|json] [<bsky.app link> ...]
+//	aturi lint      [-format text|json] [<uri> ...]
+//
+// The 'validate' command reads AT-URIs from stdin, one per line.
+// The 'normalize', 'from-url', and 'lint' commands read from their arguments, or (if there are none) from stdin, one per line.
+//
+// With "-format json" each result (including each error) is written to stdout as one JSON object per line, so that it can be used with tools such as jq.
+// Otherwise, results are written to stdout, and errors and diagnostics (such as from 'validate' and 'lint') to stderr.
+//
+// The exit status is 0 on success, 1 if any input is invalid, and 2 if the command-line is wrong.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+const usage = `usage:
+	aturi parse     [-format text|json] <uri>
+	aturi validate  [-format text|json] [-strict]       < uris.txt
+	aturi normalize [-format text|json] [<uri> ...]
+	aturi from-url  [-format text|json] [<bsky.app link> ...]
+	aturi lint      [-format text|json] [<uri> ...]
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command-line 'args' (without the program name), and returns the exit status.
+//
+// Results are written to stdout. Errors and diagnostics (in the "text" format) are written to stderr.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	var command string = args[0]
+	args = args[1:]
+
+	switch command {
+	case "parse":
+		return parse(args, stdin, stdout, stderr)
+	case "validate":
+		return validate(args, stdin, stdout, stderr)
+	case "normalize":
+		return normalize(args, stdin, stdout, stderr)
+	case "from-url":
+		return fromURL(args, stdin, stdout, stderr)
+	case "lint":
+		return lint(args, stdin, stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "aturi: unknown command %q\n", command)
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"testing"
+
+	"bytes"
+	"strings"
+)
+
+func TestRun(t *testing.T) {
+
+	tests := []struct{
+		Args []string
+		Stdin string
+		ExpectedStatus int
+		ExpectedStdout string
+		ExpectedStderr string
+	}{
+		{
+			ExpectedStatus: exitUsage,
+			ExpectedStderr: usage,
+		},
+		{
+			Args:           []string{"help"},
+			ExpectedStatus: exitOK,
+			ExpectedStdout: usage,
+		},
+		{
+			Args:           []string{"frobnicate"},
+			ExpectedStatus: exitUsage,
+			ExpectedStderr: "aturi: unknown command \"frobnicate\"\n" + usage,
+		},
+
+
+
+		{
+			Args:           []string{"parse", "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?once=1#twice"},
+			ExpectedStatus: exitOK,
+			ExpectedStdout:
+				"authority\tdid:plc:scewmn2pl3oz36mxme2b6czz\n" +
+				"collection\tapp.bsky.feed.post\n" +
+				"rkey\t3jui7kd54zh2y\n" +
+				"query\tonce=1\n" +
+				"fragment\ttwice\n",
+		},
+		{
+			Args:           []string{"parse", "-format", "json", "at://example.com/app.bsky.feed.post"},
+			ExpectedStatus: exitOK,
+			ExpectedStdout: `{"uri":"at://example.com/app.bsky.feed.post","authority":"example.com","collection":"app.bsky.feed.post"}` + "\n",
+		},
+		{
+			Args:           []string{"parse", "https://example.com/"},
+			ExpectedStatus: exitInvalid,
+			ExpectedStderr: `aturi: URI "https://example.com/" is not an at-uri because it does not begin with "at://"` + "\n",
+		},
+		{
+			Args:           []string{"parse", "-format", "json", "at://"},
+			ExpectedStatus: exitInvalid,
+			ExpectedStdout: `{"uri":"at://","error":"aturi: URI \"at://\" has an empty 'authority'"}` + "\n",
+			ExpectedStderr: `aturi: URI "at://" has an empty 'authority'` + "\n",
+		},
+		{
+			Args:           []string{"parse"},
+			ExpectedStatus: exitUsage,
+			ExpectedStderr: "usage: aturi parse [-format text|json] <uri>\n",
+		},
+		{
+			Args:           []string{"parse", "-format", "xml", "at://example.com"},
+			ExpectedStatus: exitUsage,
+			ExpectedStderr: `aturi: unknown format "xml" (expected "text" or "json")` + "\n",
+		},
+
+
+
+		{
+			Args:           []string{"validate"},
+			Stdin:          "at://example.com\nat://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y\n",
+			ExpectedStatus: exitOK,
+		},
+		{
+			Args:           []string{"validate"},
+			Stdin:          "at://example.com\nat://example.com/foorBar\nat://\n",
+			ExpectedStatus: exitInvalid,
+			ExpectedStderr: "2\taturi: URI \"at://example.com/foorBar\" has a collection \"foorBar\" that is not a valid NSID: nsid: nsid (\"foorBar\") should have at least 3 segments but actually has 1\n",
+		},
+		{
+			Args:           []string{"validate", "-format", "json"},
+			Stdin:          "at://example.com\nat://\n",
+			ExpectedStatus: exitInvalid,
+			ExpectedStdout: `{"line":2,"uri":"at://","error":"aturi: URI \"at://\" has an empty 'authority'"}` + "\n",
+		},
+		{
+			Args:           []string{"validate", "-strict"},
+			Stdin:          "at://example.com/app.bsky.feed.post/3jui7kd54zh2y?once=1\n",
+			ExpectedStatus: exitInvalid,
+			ExpectedStderr: "1\taturi: URI \"at://example.com/app.bsky.feed.post/3jui7kd54zh2y?once=1\" may not have a query\n",
+		},
+		{
+			Args:           []string{"validate", "at://example.com"},
+			ExpectedStatus: exitUsage,
+			ExpectedStderr: "usage: aturi validate [-format text|json] [-strict] < uris.txt\n",
+		},
+
+
+
+		{
+			Args:           []string{"normalize", "AT://Example.COM/app.bsky.feed.post/3jui7kd54zh2y"},
+			ExpectedStatus: exitOK,
+			ExpectedStdout: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y\n",
+		},
+		{
+			Args:           []string{"normalize"},
+			Stdin:          "AT://Example.COM\nat://did:plc:scewmn2pl3oz36mxme2b6czz\n",
+			ExpectedStatus: exitOK,
+			ExpectedStdout: "at://example.com\nat://did:plc:scewmn2pl3oz36mxme2b6czz\n",
+		},
+		{
+			Args:           []string{"normalize", "-format", "json", "AT://Example.COM", "at://"},
+			ExpectedStatus: exitInvalid,
+			ExpectedStdout:
+				`{"input":"AT://Example.COM","uri":"at://example.com"}` + "\n" +
+				`{"input":"at://","error":"aturi: URI \"at://\" has an empty 'authority'"}` + "\n",
+			ExpectedStderr: `aturi: URI "at://" has an empty 'authority'` + "\n",
+		},
+
+
+
+		{
+			Args:           []string{"from-url", "https://bsky.app/profile/reiver.bsky.social/post/3jui7kd54zh2y"},
+			ExpectedStatus: exitOK,
+			ExpectedStdout: "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y\n",
+		},
+
+
+
+		{
+			Args:           []string{"lint", "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"},
+			ExpectedStatus: exitOK,
+		},
+		{
+			Args:           []string{"lint", "-format", "json", "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"},
+			ExpectedStatus: exitOK,
+			ExpectedStdout: `{"input":"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y","diagnostics":[]}` + "\n",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+
+		status := run(test.Args, strings.NewReader(test.Stdin), &stdout, &stderr)
+
+		if expected, actual := test.ExpectedStatus, status; expected != actual {
+			t.Errorf("For test #%d, the actual exit status is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			t.Logf("ARGS: %q", test.Args)
+			t.Logf("STDERR: %q", stderr.String())
+			continue
+		}
+
+		if expected, actual := test.ExpectedStdout, stdout.String(); expected != actual {
+			t.Errorf("For test #%d, the actual stdout is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("ARGS: %q", test.Args)
+			continue
+		}
+
+		if expected, actual := test.ExpectedStderr, stderr.String(); expected != actual {
+			t.Errorf("For test #%d, the actual stderr is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("ARGS: %q", test.Args)
+			continue
+		}
+	}
+}
+
+func TestRun_lint(t *testing.T) {
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	status := run([]string{"lint",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.psot/3jui7kd54zh2y",
+		"at:/did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+	}, strings.NewReader(""), &stdout, &stderr)
+
+	if expected, actual := exitInvalid, status; expected != actual {
+		t.Errorf("The actual exit status is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+
+	if "" != stdout.String() {
+		t.Errorf("Expected nothing to be written to stdout, but something actually was: %q", stdout.String())
+	}
+
+	for _, expected := range []string{
+		`warning: the collection is not a well-known NSID; did you mean "app.bsky.feed.post"?`,
+		`error: "at:/" has only 1 slash but should have 2`,
+	} {
+		if !strings.Contains(stderr.String(), expected) {
+			t.Errorf("Expected stderr to have %q in it, but it actually did not.", expected)
+			t.Logf("STDERR: %q", stderr.String())
+		}
+	}
+}
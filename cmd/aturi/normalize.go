@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/reiver/go-aturi"
+)
+
+func normalize(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flagSet, format := newFlagSet("normalize", stderr)
+	if err := flagSet.Parse(args); nil != err {
+		return exitUsage
+	}
+	if err := checkFormat(*format); nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	err := eachInput(flagSet.Args(), stdin, func(input string) error {
+		uri, err := aturi.Normalize(input)
+		writeResult(stdout, *format, input, uri, err)
+		return err
+	})
+	if nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+// writeResult writes the result of converting one input into an AT-URI.
+//
+// In the "text" format only the AT-URI is written (errors are written to stderr by the caller).
+func writeResult(stdout io.Writer, format string, input string, uri string, err error) {
+	switch format {
+	case formatJSON:
+		var result = struct{
+			Input string `json:"input"`
+			URI   string `json:"uri,omitempty"`
+			Error string `json:"error,omitempty"`
+		}{
+			Input: input,
+			URI:   uri,
+		}
+		if nil != err {
+			result.Error = err.Error()
+		}
+		writeJSON(stdout, result)
+	default:
+		if nil != err {
+			return
+		}
+		fmt.Fprintln(stdout, uri)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// newFlagSet returns a flag-set for a command, with the "-format" flag that every command has.
+// Usage and errors from parsing flags are written to stderr.
+func newFlagSet(command string, stderr io.Writer) (*flag.FlagSet, *string) {
+	var flagSet *flag.FlagSet = flag.NewFlagSet("aturi "+command, flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+
+	var format *string = flagSet.String("format", formatText, "output format: \"text\" or \"json\"")
+
+	return flagSet, format
+}
+
+// checkFormat returns an error if the format is not one that is supported.
+func checkFormat(format string) error {
+	switch format {
+	case formatText, formatJSON:
+		return nil
+	default:
+		return fmt.Errorf("aturi: unknown format %q (expected %q or %q)", format, formatText, formatJSON)
+	}
+}
+
+// writeJSON writes the value as a single line of JSON.
+func writeJSON(writer io.Writer, value any) {
+	var encoder *json.Encoder = json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+}
+
+// eachInput calls fn for each of the arguments, or (if there are no arguments) for each line of stdin.
+// It stops at (and returns) the first error that fn or reading stdin returns.
+func eachInput(args []string, stdin io.Reader, fn func(string) error) error {
+	if 0 < len(args) {
+		for _, arg := range args {
+			if err := fn(arg); nil != err {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var scanner *bufio.Scanner = newLineScanner(stdin)
+	for scanner.Scan() {
+		if err := fn(scanner.Text()); nil != err {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// newLineScanner returns a scanner that reads one line at a time.
+//
+// Its buffer is large enough that a too-long AT-URI gets reported as too long, rather than making the scanner fail.
+func newLineScanner(reader io.Reader) *bufio.Scanner {
+	const max int = 1024 * 1024
+
+	var scanner *bufio.Scanner = bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), max)
+
+	return scanner
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/reiver/go-aturi"
+)
+
+func parse(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flagSet, format := newFlagSet("parse", stderr)
+	if err := flagSet.Parse(args); nil != err {
+		return exitUsage
+	}
+	if err := checkFormat(*format); nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	if 1 != flagSet.NArg() {
+		fmt.Fprintln(stderr, "usage: aturi parse [-format text|json] <uri>")
+		return exitUsage
+	}
+
+	var uri string = flagSet.Arg(0)
+
+	authority, collection, rkey, query, fragment, err := aturi.Split(uri)
+
+	switch *format {
+	case formatJSON:
+		var result = struct{
+			URI        string `json:"uri"`
+			Authority  string `json:"authority,omitempty"`
+			Collection string `json:"collection,omitempty"`
+			RKey       string `json:"rkey,omitempty"`
+			Query      string `json:"query,omitempty"`
+			Fragment   string `json:"fragment,omitempty"`
+			Error      string `json:"error,omitempty"`
+		}{
+			URI:        uri,
+			Authority:  authority,
+			Collection: collection,
+			RKey:       rkey,
+			Query:      query,
+			Fragment:   fragment,
+		}
+		if nil != err {
+			result.Error = err.Error()
+		}
+		writeJSON(stdout, result)
+	default:
+		if nil != err {
+			break
+		}
+		fmt.Fprintf(stdout, "authority\t%s\n",  authority)
+		fmt.Fprintf(stdout, "collection\t%s\n", collection)
+		fmt.Fprintf(stdout, "rkey\t%s\n",       rkey)
+		fmt.Fprintf(stdout, "query\t%s\n",      query)
+		fmt.Fprintf(stdout, "fragment\t%s\n",   fragment)
+	}
+
+	if nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
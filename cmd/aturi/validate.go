@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/reiver/go-aturi"
+)
+
+func validate(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flagSet, format := newFlagSet("validate", stderr)
+	var strict *bool = flagSet.Bool("strict", false, "use aturi.ValidateStrict rather than aturi.Validate")
+	if err := flagSet.Parse(args); nil != err {
+		return exitUsage
+	}
+	if err := checkFormat(*format); nil != err {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	if 0 != flagSet.NArg() {
+		fmt.Fprintln(stderr, "usage: aturi validate [-format text|json] [-strict] < uris.txt")
+		return exitUsage
+	}
+
+	var fn func(string) error = aturi.Validate
+	if *strict {
+		fn = aturi.ValidateStrict
+	}
+
+	var scanner = newLineScanner(stdin)
+
+	var line int
+	for scanner.Scan() {
+		line++
+
+		var uri string = scanner.Text()
+
+		err := fn(uri)
+		if nil == err {
+			continue
+		}
+
+		switch *format {
+		case formatJSON:
+			writeJSON(stdout, struct{
+				Line  int    `json:"line"`
+				URI   string `json:"uri"`
+				Error string `json:"error"`
+			}{
+				Line:  line,
+				URI:   uri,
+				Error: err.Error(),
+			})
+		default:
+			fmt.Fprintf(stderr, "%d\t%s\n", line, err)
+		}
+		return exitInvalid
+	}
+	if err := scanner.Err(); nil != err {
+		fmt.Fprintf(stderr, "aturi: problem reading line %d: %s\n", line+1, err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
@@ -0,0 +1,20 @@
+// Command aturicheck checks AT-URIs in Go code (see package [github.com/reiver/go-aturi/aturicheck]).
+//
+// Usage:
+//
+//	aturicheck [-fix] [packages]
+//
+// Or with go vet:
+//
+//	go vet -vettool=$(which aturicheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/reiver/go-aturi/aturicheck"
+)
+
+func main() {
+	singlechecker.Main(aturicheck.Analyzer)
+}
@@ -0,0 +1,65 @@
+package aturi
+
+import (
+	"sort"
+)
+
+//go:generate go run ./internal/gencollections -dir lexicons -out collections.go
+
+// CollectionNSID is the NSID of a well-known collection, such as [CollectionFeedPost] ("app.bsky.feed.post").
+//
+// The constants are generated (by "go generate") from the Lexicon JSON files in "lexicons",
+// so that the NSIDs never have to be typed by hand.
+type CollectionNSID string
+
+// String returns the NSID.
+func (receiver CollectionNSID) String() string {
+	return string(receiver)
+}
+
+// RecordKeyType is the type of record-key (rkey) that the records of a collection use,
+// as declared by the "key" of the record's Lexicon.
+//
+// It is one of "tid", "nsid", "any", or "literal:<value>" (such as "literal:self").
+type RecordKeyType string
+
+const (
+	RecordKeyTID  RecordKeyType = "tid"  // the rkey is a TID
+	RecordKeyNSID RecordKeyType = "nsid" // the rkey is an NSID
+	RecordKeyAny  RecordKeyType = "any"  // the rkey is any valid record-key
+)
+
+// CollectionMetadata is what is known about a well-known collection.
+type CollectionMetadata struct {
+	NSID        CollectionNSID
+	RecordKey   RecordKeyType
+	Description string // the description from the record's Lexicon
+}
+
+// CollectionInfo returns what is known about a well-known collection,
+// or false if the collection is not one of the well-known ones.
+//
+// For example:
+//
+//	info, found := aturi.CollectionInfo("app.bsky.actor.profile")
+//	
+//	// info.RecordKey == "literal:self"
+//	// found         == true
+func CollectionInfo(nsid string) (CollectionMetadata, bool) {
+	info, found := collectionInfos[nsid]
+	return info, found
+}
+
+// Collections returns all the well-known collections, sorted by NSID.
+func Collections() []CollectionMetadata {
+	var collections []CollectionMetadata = make([]CollectionMetadata, 0, len(collectionInfos))
+	for _, info := range collectionInfos {
+		collections = append(collections, info)
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].NSID < collections[j].NSID
+	})
+
+	return collections
+}
@@ -0,0 +1,189 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestCollectionInfo(t *testing.T) {
+
+	tests := []struct{
+		NSID string
+		Expected aturi.CollectionMetadata
+		ExpectedFound bool
+	}{
+		{
+			NSID: "app.bsky.feed.post",
+			Expected: aturi.CollectionMetadata{
+				NSID:        aturi.CollectionFeedPost,
+				RecordKey:   aturi.RecordKeyTID,
+				Description: "Record containing a Bluesky post.",
+			},
+			ExpectedFound: true,
+		},
+		{
+			NSID: "app.bsky.actor.profile",
+			Expected: aturi.CollectionMetadata{
+				NSID:        aturi.CollectionActorProfile,
+				RecordKey:   "literal:self",
+				Description: "A declaration of a Bluesky account profile.",
+			},
+			ExpectedFound: true,
+		},
+		{
+			NSID:          "app.bsky.feed.Post",
+		},
+		{
+			NSID:          "com.example.fooBar",
+		},
+		{
+			NSID:          "",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, found := aturi.CollectionInfo(test.NSID)
+
+		if expected := test.ExpectedFound; expected != found {
+			t.Errorf("For test #%d, the actual 'found' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %t", expected)
+			t.Logf("ACTUAL:   %t", found)
+			t.Logf("NSID: %q", test.NSID)
+			continue
+		}
+
+		if expected := test.Expected; expected != actual {
+			t.Errorf("For test #%d, the actual collection info is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			t.Logf("NSID: %q", test.NSID)
+			continue
+		}
+	}
+}
+
+func TestCollections(t *testing.T) {
+
+	var collections []aturi.CollectionMetadata = aturi.Collections()
+
+	if expected, actual := 13, len(collections); expected != actual {
+		t.Errorf("The actual number of collections is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+		return
+	}
+
+	for index, info := range collections {
+		if 0 < index && !(collections[index-1].NSID < info.NSID) {
+			t.Errorf("For collection #%d, it is not sorted after the collection before it.", index)
+			t.Logf("BEFORE: %q", collections[index-1].NSID)
+			t.Logf("ACTUAL: %q", info.NSID)
+			continue
+		}
+
+		var uri string = aturi.Join("did:plc:scewmn2pl3oz36mxme2b6czz", info.NSID.String(), "3jui7kd54zh2y", "", "")
+		if err := aturi.ValidateStrict(uri); nil != err {
+			t.Errorf("For collection #%d, did not expect an error but actually got one.", index)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("NSID: %q", info.NSID)
+			continue
+		}
+
+		if "" == info.Description {
+			t.Errorf("For collection #%d, expected a description but did not actually get one.", index)
+			t.Logf("NSID: %q", info.NSID)
+			continue
+		}
+	}
+}
+
+func TestCollection_markers(t *testing.T) {
+
+	tests := []struct{
+		Collection aturi.Collection
+		Expected aturi.CollectionNSID
+	}{
+		{aturi.ActorProfile{},     aturi.CollectionActorProfile},
+		{aturi.FeedPost{},         aturi.CollectionFeedPost},
+		{aturi.GraphStarterpack{}, aturi.CollectionGraphStarterpack},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.Expected.String(), test.Collection.NSID(); expected != actual {
+			t.Errorf("For test #%d, the actual NSID is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+// TestCollections_generated checks that collections.go is up to date with the Lexicon JSON files it is generated from.
+// If it fails, run "go generate".
+func TestCollections_generated(t *testing.T) {
+
+	var count int
+
+	err := filepath.WalkDir("lexicons", func(path string, entry fs.DirEntry, err error) error {
+		if nil != err {
+			return err
+		}
+		if entry.IsDir() || ".json" != filepath.Ext(path) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if nil != err {
+			return err
+		}
+
+		var doc struct {
+			ID   string `json:"id"`
+			Defs map[string]struct{
+				Type string `json:"type"`
+				Key  string `json:"key"`
+			} `json:"defs"`
+		}
+		if err := json.Unmarshal(data, &doc); nil != err {
+			return err
+		}
+		if "record" != doc.Defs["main"].Type {
+			return nil
+		}
+		count++
+
+		info, found := aturi.CollectionInfo(doc.ID)
+		if !found {
+			t.Errorf("Lexicon file %q has a record %q that is not in the registry (run \"go generate\").", path, doc.ID)
+			return nil
+		}
+
+		if expected, actual := doc.Defs["main"].Key, string(info.RecordKey); expected != actual {
+			t.Errorf("For Lexicon file %q, the actual record-key type is not what was expected (run \"go generate\").", path)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+		}
+
+		return nil
+	})
+	if nil != err {
+		t.Errorf("Did not expect an error but actually got one.")
+		t.Logf("ERROR: (%T) %s", err, err)
+		return
+	}
+
+	if expected, actual := count, len(aturi.Collections()); expected != actual {
+		t.Errorf("The actual number of collections in the registry is not what was expected (run \"go generate\").")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+		return
+	}
+}
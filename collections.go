@@ -0,0 +1,158 @@
+// Code generated by gencollections from Lexicon JSON files; DO NOT EDIT.
+
+package aturi
+
+// These are the [Collection] marker types for the well-known record types.
+type (
+	ActorProfile     struct{} // app.bsky.actor.profile
+	FeedGenerator    struct{} // app.bsky.feed.generator
+	FeedLike         struct{} // app.bsky.feed.like
+	FeedPost         struct{} // app.bsky.feed.post
+	FeedPostgate     struct{} // app.bsky.feed.postgate
+	FeedRepost       struct{} // app.bsky.feed.repost
+	FeedThreadgate   struct{} // app.bsky.feed.threadgate
+	GraphBlock       struct{} // app.bsky.graph.block
+	GraphFollow      struct{} // app.bsky.graph.follow
+	GraphList        struct{} // app.bsky.graph.list
+	GraphListblock   struct{} // app.bsky.graph.listblock
+	GraphListitem    struct{} // app.bsky.graph.listitem
+	GraphStarterpack struct{} // app.bsky.graph.starterpack
+)
+
+func (ActorProfile) NSID() string     { return string(CollectionActorProfile) }
+func (FeedGenerator) NSID() string    { return string(CollectionFeedGenerator) }
+func (FeedLike) NSID() string         { return string(CollectionFeedLike) }
+func (FeedPost) NSID() string         { return string(CollectionFeedPost) }
+func (FeedPostgate) NSID() string     { return string(CollectionFeedPostgate) }
+func (FeedRepost) NSID() string       { return string(CollectionFeedRepost) }
+func (FeedThreadgate) NSID() string   { return string(CollectionFeedThreadgate) }
+func (GraphBlock) NSID() string       { return string(CollectionGraphBlock) }
+func (GraphFollow) NSID() string      { return string(CollectionGraphFollow) }
+func (GraphList) NSID() string        { return string(CollectionGraphList) }
+func (GraphListblock) NSID() string   { return string(CollectionGraphListblock) }
+func (GraphListitem) NSID() string    { return string(CollectionGraphListitem) }
+func (GraphStarterpack) NSID() string { return string(CollectionGraphStarterpack) }
+
+// These are the NSIDs of the well-known record types.
+const (
+	// CollectionActorProfile is the collection for records of type app.bsky.actor.profile (record-key: literal:self).
+	// A declaration of a Bluesky account profile.
+	CollectionActorProfile CollectionNSID = "app.bsky.actor.profile"
+
+	// CollectionFeedGenerator is the collection for records of type app.bsky.feed.generator (record-key: any).
+	// Record declaring of the existence of a feed generator, and containing metadata about it. The record can exist in any repository.
+	CollectionFeedGenerator CollectionNSID = "app.bsky.feed.generator"
+
+	// CollectionFeedLike is the collection for records of type app.bsky.feed.like (record-key: tid).
+	// Record declaring a 'like' of a piece of subject content.
+	CollectionFeedLike CollectionNSID = "app.bsky.feed.like"
+
+	// CollectionFeedPost is the collection for records of type app.bsky.feed.post (record-key: tid).
+	// Record containing a Bluesky post.
+	CollectionFeedPost CollectionNSID = "app.bsky.feed.post"
+
+	// CollectionFeedPostgate is the collection for records of type app.bsky.feed.postgate (record-key: tid).
+	// Record defining interaction rules for a post. The record key (rkey) of the postgate record must match the record key of the post, and that record must be in the same repository.
+	CollectionFeedPostgate CollectionNSID = "app.bsky.feed.postgate"
+
+	// CollectionFeedRepost is the collection for records of type app.bsky.feed.repost (record-key: tid).
+	// Record representing a 'repost' of an existing Bluesky post.
+	CollectionFeedRepost CollectionNSID = "app.bsky.feed.repost"
+
+	// CollectionFeedThreadgate is the collection for records of type app.bsky.feed.threadgate (record-key: tid).
+	// Record defining interaction gating rules for a thread (aka, reply controls). The record key (rkey) of the threadgate record must match the record key of the thread's root post, and that record must be in the same repository.
+	CollectionFeedThreadgate CollectionNSID = "app.bsky.feed.threadgate"
+
+	// CollectionGraphBlock is the collection for records of type app.bsky.graph.block (record-key: tid).
+	// Record declaring a 'block' relationship against another account. NOTE: blocks are public in Bluesky; see blog posts for details.
+	CollectionGraphBlock CollectionNSID = "app.bsky.graph.block"
+
+	// CollectionGraphFollow is the collection for records of type app.bsky.graph.follow (record-key: tid).
+	// Record declaring a social 'follow' relationship of another account. Duplicate follows will be ignored by the AppView.
+	CollectionGraphFollow CollectionNSID = "app.bsky.graph.follow"
+
+	// CollectionGraphList is the collection for records of type app.bsky.graph.list (record-key: tid).
+	// Record representing a list of accounts (actors). Scope includes both moderation-oriented lists and curration-oriented lists.
+	CollectionGraphList CollectionNSID = "app.bsky.graph.list"
+
+	// CollectionGraphListblock is the collection for records of type app.bsky.graph.listblock (record-key: tid).
+	// Record representing a block relationship against an entire an entire list of accounts (actors).
+	CollectionGraphListblock CollectionNSID = "app.bsky.graph.listblock"
+
+	// CollectionGraphListitem is the collection for records of type app.bsky.graph.listitem (record-key: tid).
+	// Record representing an account's inclusion on a specific list. The AppView will ignore duplicate listitem records.
+	CollectionGraphListitem CollectionNSID = "app.bsky.graph.listitem"
+
+	// CollectionGraphStarterpack is the collection for records of type app.bsky.graph.starterpack (record-key: tid).
+	// Record defining a starter pack of actors and feeds for new users.
+	CollectionGraphStarterpack CollectionNSID = "app.bsky.graph.starterpack"
+)
+
+// collectionInfos is the registry of well-known collections that [CollectionInfo] looks things up in.
+var collectionInfos = map[string]CollectionMetadata{
+	"app.bsky.actor.profile": {
+		NSID:        CollectionActorProfile,
+		RecordKey:   "literal:self",
+		Description: "A declaration of a Bluesky account profile.",
+	},
+	"app.bsky.feed.generator": {
+		NSID:        CollectionFeedGenerator,
+		RecordKey:   "any",
+		Description: "Record declaring of the existence of a feed generator, and containing metadata about it. The record can exist in any repository.",
+	},
+	"app.bsky.feed.like": {
+		NSID:        CollectionFeedLike,
+		RecordKey:   "tid",
+		Description: "Record declaring a 'like' of a piece of subject content.",
+	},
+	"app.bsky.feed.post": {
+		NSID:        CollectionFeedPost,
+		RecordKey:   "tid",
+		Description: "Record containing a Bluesky post.",
+	},
+	"app.bsky.feed.postgate": {
+		NSID:        CollectionFeedPostgate,
+		RecordKey:   "tid",
+		Description: "Record defining interaction rules for a post. The record key (rkey) of the postgate record must match the record key of the post, and that record must be in the same repository.",
+	},
+	"app.bsky.feed.repost": {
+		NSID:        CollectionFeedRepost,
+		RecordKey:   "tid",
+		Description: "Record representing a 'repost' of an existing Bluesky post.",
+	},
+	"app.bsky.feed.threadgate": {
+		NSID:        CollectionFeedThreadgate,
+		RecordKey:   "tid",
+		Description: "Record defining interaction gating rules for a thread (aka, reply controls). The record key (rkey) of the threadgate record must match the record key of the thread's root post, and that record must be in the same repository.",
+	},
+	"app.bsky.graph.block": {
+		NSID:        CollectionGraphBlock,
+		RecordKey:   "tid",
+		Description: "Record declaring a 'block' relationship against another account. NOTE: blocks are public in Bluesky; see blog posts for details.",
+	},
+	"app.bsky.graph.follow": {
+		NSID:        CollectionGraphFollow,
+		RecordKey:   "tid",
+		Description: "Record declaring a social 'follow' relationship of another account. Duplicate follows will be ignored by the AppView.",
+	},
+	"app.bsky.graph.list": {
+		NSID:        CollectionGraphList,
+		RecordKey:   "tid",
+		Description: "Record representing a list of accounts (actors). Scope includes both moderation-oriented lists and curration-oriented lists.",
+	},
+	"app.bsky.graph.listblock": {
+		NSID:        CollectionGraphListblock,
+		RecordKey:   "tid",
+		Description: "Record representing a block relationship against an entire an entire list of accounts (actors).",
+	},
+	"app.bsky.graph.listitem": {
+		NSID:        CollectionGraphListitem,
+		RecordKey:   "tid",
+		Description: "Record representing an account's inclusion on a specific list. The AppView will ignore duplicate listitem records.",
+	},
+	"app.bsky.graph.starterpack": {
+		NSID:        CollectionGraphStarterpack,
+		RecordKey:   "tid",
+		Description: "Record defining a starter pack of actors and feeds for new users.",
+	},
+}
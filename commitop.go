@@ -0,0 +1,87 @@
+package aturi
+
+import (
+	"github.com/reiver/go-erorr"
+)
+
+// CommitAction is what a firehose commit operation did to a record.
+type CommitAction string
+
+const (
+	CommitActionCreate CommitAction = "create"
+	CommitActionUpdate CommitAction = "update"
+	CommitActionDelete CommitAction = "delete"
+)
+
+// CommitOp is an operation in a "#commit" event of the "com.atproto.sync.subscribeRepos" firehose.
+//
+// To unmarshal CommitOps from the (DAG-)CBOR of the firehose (where the CID is a CID link), use [github.com/reiver/go-aturi/cbor.CommitOp].
+type CommitOp struct {
+	Action string // "create", "update", or "delete"
+	Path   string // the repo path of the record, i.e., "<collection>/<rkey>"
+	CID    string // the CID of the new version of the record (in its base32 string form), or "" for a "delete"
+}
+
+// CommitEvent is a [CommitOp] turned into an AT-URI (see [CommitEvents]).
+type CommitEvent struct {
+	Action CommitAction
+
+	URI        string // at://<repo>/<collection>/<rkey>
+	Repo       string // the DID of the repo (which is also the authority of the AT-URI)
+	Collection string
+	RKey       string
+
+	CID string // the CID of the new version of the record, or "" for a delete
+}
+
+// CommitEvents turns the operations of a firehose "#commit" event into [CommitEvent]s, and calls fn for each one (in order).
+// If fn returns false, CommitEvents stops.
+//
+// 'repo' must be a DID.
+// Each path must be a valid repo path (see [SplitPath]).
+// A "create" or "update" must have a CID, and a "delete" must not.
+//
+// CommitEvents stops and returns an error at the first operation that is not valid.
+// A problem with a path is returned as an [*Error].
+func CommitEvents(repo string, ops []CommitOp, fn func(CommitEvent) bool) error {
+	if err := validateDID(repo); nil != err {
+		return erorr.Errorf("aturi: commit repo %q is not a valid DID: %w", repo, err)
+	}
+
+	for index, op := range ops {
+		var action CommitAction = CommitAction(op.Action)
+
+		switch action {
+		case CommitActionCreate, CommitActionUpdate:
+			if err := validateCID(op.CID); nil != err {
+				return erorr.Errorf("aturi: commit operation №%d (%s %q) has an invalid CID: %w", index, action, op.Path, err)
+			}
+		case CommitActionDelete:
+			if "" != op.CID {
+				return erorr.Errorf("aturi: commit operation №%d (%s %q) may not have a CID", index, action, op.Path)
+			}
+		default:
+			return erorr.Errorf("aturi: commit operation №%d (on %q) has an unknown action %q", index, op.Path, op.Action)
+		}
+
+		collection, rkey, err := SplitPath(op.Path)
+		if nil != err {
+			return err
+		}
+
+		var event = CommitEvent{
+			Action:     action,
+			URI:        Join(repo, collection, rkey, "", ""),
+			Repo:       repo,
+			Collection: collection,
+			RKey:       rkey,
+			CID:        op.CID,
+		}
+
+		if nil != fn && !fn(event) {
+			return nil
+		}
+	}
+
+	return nil
+}
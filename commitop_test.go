@@ -0,0 +1,57 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestCommitEvents_fail(t *testing.T) {
+
+	const cid string = "bafyreidsemiehpaya7tpoqfsgxvxkepmwmzfljvdovbvmmiznxuks5injm"
+
+	tests := []struct{
+		Repo string
+		Op   aturi.CommitOp
+	}{
+		{
+			Repo: "example.com", // not a DID
+			Op:   aturi.CommitOp{Action: "create", Path: "app.bsky.feed.post/3l3qo2vuowo2b", CID: cid},
+		},
+		{
+			Repo: "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Op:   aturi.CommitOp{Action: "upsert", Path: "app.bsky.feed.post/3l3qo2vuowo2b", CID: cid},
+		},
+		{
+			Repo: "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Op:   aturi.CommitOp{Action: "create", Path: "app.bsky.feed.post/3l3qo2vuowo2b"},
+		},
+		{
+			Repo: "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Op:   aturi.CommitOp{Action: "delete", Path: "app.bsky.feed.post/3l3qo2vuowo2b", CID: cid},
+		},
+		{
+			Repo: "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Op:   aturi.CommitOp{Action: "update", Path: "app.bsky.feed.post/3l3qo2vuowo2b/extra", CID: cid},
+		},
+		{
+			Repo: "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Op:   aturi.CommitOp{Action: "update", Path: "feed/3l3qo2vuowo2b", CID: cid},
+		},
+		{
+			Repo: "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Op:   aturi.CommitOp{Action: "update", Path: "app.bsky.feed.post/..", CID: cid},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		err := aturi.CommitEvents(test.Repo, []aturi.CommitOp{test.Op}, nil)
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("REPO: %q", test.Repo)
+			t.Logf("OP:   %#v", test.Op)
+			continue
+		}
+	}
+}
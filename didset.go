@@ -0,0 +1,85 @@
+package aturi
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// DIDSet is a set of DIDs, such as for the AllowDIDs or DenyDIDs of a [Policy].
+type DIDSet map[string]struct{}
+
+// NewDIDSet returns a DIDSet with the DIDs in it.
+// It returns an error if any of them is not a valid DID.
+func NewDIDSet(dids ...string) (DIDSet, error) {
+	var set = DIDSet{}
+
+	for _, did := range dids {
+		if err := validateDID(did); nil != err {
+			return nil, err
+		}
+		set[did] = struct{}{}
+	}
+
+	return set, nil
+}
+
+// ReadDIDSet reads a DIDSet from 'reader', one DID per line.
+//
+// Blank lines, and lines beginning with a '#', are ignored.
+// Spaces around a DID are ignored.
+func ReadDIDSet(reader io.Reader) (DIDSet, error) {
+	if nil == reader {
+		return nil, errNilReader
+	}
+
+	var set = DIDSet{}
+
+	var scanner *bufio.Scanner = bufio.NewScanner(reader)
+
+	var lineNumber int
+	for scanner.Scan() {
+		lineNumber++
+
+		var line string = strings.TrimSpace(scanner.Text())
+		if "" == line || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := validateDID(line); nil != err {
+			return nil, erorr.Errorf("aturi: line %d of DID list: %w", lineNumber, err)
+		}
+
+		set[line] = struct{}{}
+	}
+	if err := scanner.Err(); nil != err {
+		return nil, erorr.Errorf("aturi: problem reading line %d of DID list: %w", lineNumber+1, err)
+	}
+
+	return set, nil
+}
+
+// LoadDIDSet is like [ReadDIDSet] but reads the DIDs from the file with the name 'name'.
+func LoadDIDSet(name string) (DIDSet, error) {
+	file, err := os.Open(name)
+	if nil != err {
+		return nil, err
+	}
+	defer file.Close()
+
+	set, err := ReadDIDSet(file)
+	if nil != err {
+		return nil, erorr.Errorf("aturi: problem loading DID list %q: %w", name, err)
+	}
+
+	return set, nil
+}
+
+// Has returns whether the DID is in the set.
+func (receiver DIDSet) Has(did string) bool {
+	_, found := receiver[did]
+	return found
+}
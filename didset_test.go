@@ -0,0 +1,50 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestLoadDIDSet(t *testing.T) {
+
+	set, err := aturi.LoadDIDSet("testdata/denied-dids.txt")
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	if expected, actual := 2, len(set); expected != actual {
+		t.Errorf("The actual number of DIDs is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+
+	for _, did := range []string{"did:plc:ewvi7nxzyoun6zhxrhs64oiz", "did:web:spam.example.com"} {
+		if !set.Has(did) {
+			t.Errorf("Expected the set to have %q but it actually does not.", did)
+		}
+	}
+
+	if set.Has("did:plc:scewmn2pl3oz36mxme2b6czz") {
+		t.Errorf("Did not expect the set to have %q but it actually does.", "did:plc:scewmn2pl3oz36mxme2b6czz")
+	}
+}
+
+func TestReadDIDSet_fail(t *testing.T) {
+
+	tests := []string{
+		"did:plc:ewvi7nxzyoun6zhxrhs64oiz\nexample.com\n",
+		"did:PLC:ewvi7nxzyoun6zhxrhs64oiz\n",
+	}
+
+	for testNumber, test := range tests {
+
+		if _, err := aturi.ReadDIDSet(strings.NewReader(test)); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("DATA: %q", test)
+			continue
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package aturi
+
+// ErrorKind says what kind of problem made an AT-URI invalid.
+type ErrorKind string
+
+const (
+	ErrorKindEmpty      ErrorKind = "empty"      // the AT-URI is empty
+	ErrorKindTooLong    ErrorKind = "too-long"   // the AT-URI is too long
+	ErrorKindScheme     ErrorKind = "scheme"     // the AT-URI does not begin with "at://"
+	ErrorKindAuthority  ErrorKind = "authority"  // the authority is invalid
+	ErrorKindCollection ErrorKind = "collection" // the collection is invalid
+	ErrorKindRecordKey  ErrorKind = "rkey"       // the rkey is invalid
+	ErrorKindPath       ErrorKind = "path"       // the path has the wrong shape (such as a trailing slash)
+	ErrorKindQuery      ErrorKind = "query"      // the query is not allowed
+	ErrorKindFragment   ErrorKind = "fragment"   // the fragment is not allowed
+	ErrorKindPolicy     ErrorKind = "policy"     // the AT-URI is valid, but a Policy does not allow it
+)
+
+// Error is the error returned when an AT-URI is invalid.
+//
+// Use [errors.As] to get at it. For example:
+//
+//	err := aturi.Validate(uri)
+//	
+//	var aturiError *aturi.Error
+//	if errors.As(err, &aturiError) {
+//		fmt.Println("kind:", aturiError.Kind)
+//	}
+type Error struct {
+	Kind ErrorKind // what kind of problem made the AT-URI invalid
+	URI  string    // the invalid AT-URI
+	Err  error     // the underlying error
+}
+
+var _ error = &Error{}
+
+func newError(kind ErrorKind, uri string, err error) error {
+	return &Error{
+		Kind: kind,
+		URI:  uri,
+		Err:  err,
+	}
+}
+
+// Error returns the error message.
+func (receiver *Error) Error() string {
+	if nil == receiver.Err {
+		return "aturi: invalid URI"
+	}
+	return receiver.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (receiver *Error) Unwrap() error {
+	return receiver.Err
+}
@@ -0,0 +1,137 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"errors"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestError_kind(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		Strict bool
+		ExpectedKind aturi.ErrorKind
+	}{
+		{
+			URI: "",
+			ExpectedKind: aturi.ErrorKindEmpty,
+		},
+		{
+			URI: "at://example.com/com.example.foorBar/" + string(make([]byte, 8192)),
+			ExpectedKind: aturi.ErrorKindTooLong,
+		},
+		{
+			URI: "https://example.com",
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+		{
+			URI: "at://",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			URI: "at://@example.com",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			URI: "at://example.com/foorBar",
+			ExpectedKind: aturi.ErrorKindCollection,
+		},
+
+
+
+		{
+			URI: "AT://example.com",
+			Strict: true,
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+		{
+			URI: "at://example",
+			Strict: true,
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			URI: "at://example.com/",
+			Strict: true,
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			URI: "at://example.com//3jui7kd54zh2y",
+			Strict: true,
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			URI: "at://example.com/com.example.foorBar/3jui7kd54zh2y/more",
+			Strict: true,
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			URI: "at://example.com/com.example.foorBar/3jui7kd54zh2y!",
+			Strict: true,
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			URI: "at://example.com/com.example.foorBar/3jui7kd54zh2y?once=1",
+			Strict: true,
+			ExpectedKind: aturi.ErrorKindQuery,
+		},
+		{
+			URI: "at://example.com/com.example.foorBar/3jui7kd54zh2y#path",
+			Strict: true,
+			ExpectedKind: aturi.ErrorKindFragment,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var err error
+		switch test.Strict {
+		case true:
+			err = aturi.ValidateStrict(test.URI)
+		default:
+			err = aturi.Validate(test.URI)
+		}
+
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		var aturiError *aturi.Error
+		if !errors.As(err, &aturiError) {
+			t.Errorf("For test #%d, expected the error to be an *aturi.Error but actually was not.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		{
+			expected := test.ExpectedKind
+			actual   := aturiError.Kind
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual 'kind' is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+				t.Logf("ERROR: %s", err)
+				t.Logf("URI: %q", test.URI)
+				continue
+			}
+		}
+
+		{
+			expected := test.URI
+			actual   := aturiError.URI
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual 'uri' is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+				continue
+			}
+		}
+	}
+}
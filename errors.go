@@ -5,5 +5,16 @@ import (
 )
 
 const (
-	errEmptyURI = erorr.Error("aturi: empty URI")
+	errEmptyCID       = erorr.Error("aturi: empty CID")
+	errEmptyDID       = erorr.Error("aturi: empty DID")
+	errEmptyHandle    = erorr.Error("aturi: empty handle")
+	errEmptyPath      = erorr.Error("aturi: empty path")
+	errEmptyPattern   = erorr.Error("aturi: empty pattern")
+	errEmptyRecordKey = erorr.Error("aturi: empty record-key")
+	errEmptyTemplate  = erorr.Error("aturi: empty template")
+	errEmptyURI       = erorr.Error("aturi: empty URI")
+	errEmptyURL       = erorr.Error("aturi: empty URL")
+	errNilURL         = erorr.Error("aturi: nil URL")
+	errNilReader      = erorr.Error("aturi: nil reader")
+	errNilReceiver    = erorr.Error("aturi: nil receiver")
 )
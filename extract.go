@@ -0,0 +1,263 @@
+package aturi
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/reiver/go-erorr"
+)
+
+// Match is an AT-URI found in text by [Extract] or [ExtractReader].
+type Match struct {
+	Begin int64 // byte offset of the first byte of the AT-URI
+	End   int64 // byte offset just past the last byte of the AT-URI
+
+	URI        string
+	Authority  string
+	Collection string
+	RKey       string
+	Query      string
+	Fragment   string
+}
+
+// Extract returns every AT-URI in the text, along with its byte offsets.
+//
+// An AT-URI begins with "at://" (in any case) that does not come right after a letter, digit, '+', '-', or '.'.
+// It ends at the first whitespace, control character, '<', '>', '"', or '`'.
+// Then trailing punctuation that is probably part of the prose, rather than part of the AT-URI, is removed:
+// any of '.', ',', ';', ':', '!', '?', '\'', '"',
+// any Unicode quotation mark (such as '»' or '”'),
+// and any of ')', ']', '}' that does not have a matching '(', '[', '{' in the AT-URI.
+//
+// Only what [Split] accepts is returned.
+//
+// For example:
+//
+//	text := "Please look at this (at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y)."
+//	
+//	matches := aturi.Extract(text)
+//	
+//	// matches[0].URI   == "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+//	// matches[0].Begin == 21
+//	// matches[0].End   == 91
+func Extract(text string) []Match {
+	var matches []Match
+
+	extract(text, 0, func(match Match) {
+		matches = append(matches, match)
+	})
+
+	return matches
+}
+
+// ExtractReader is like [Extract], but reads the text from an io.Reader, and calls fn for each AT-URI found (in order).
+//
+// The byte offsets are from the beginning of what is read from 'reader'.
+//
+// ExtractReader reads the text a word at a time (since an AT-URI never has whitespace in it).
+// A "word" longer than 1 mebibyte is an error.
+func ExtractReader(reader io.Reader, fn func(Match)) error {
+	if nil == reader {
+		return errNilReader
+	}
+	if nil == fn {
+		return nil
+	}
+
+	var scanner *bufio.Scanner = bufio.NewScanner(reader)
+	{
+		const max int = 1024 * 1024
+
+		scanner.Buffer(make([]byte, 0, 64*1024), max)
+		scanner.Split(scanWordsWithSpace)
+	}
+
+	var offset int64
+	for scanner.Scan() {
+		var token string = scanner.Text()
+
+		extract(token, offset, fn)
+
+		offset += int64(len(token))
+	}
+	if err := scanner.Err(); nil != err {
+		return erorr.Errorf("aturi: problem reading text at byte offset %d: %w", offset, err)
+	}
+
+	return nil
+}
+
+// scanWordsWithSpace is a bufio.SplitFunc that returns a word along with the whitespace after it,
+// so that the lengths of the tokens add up to the byte offset in the text.
+func scanWordsWithSpace(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	var inSpace bool
+	for index := 0; index < len(data); {
+		r, size := utf8.DecodeRune(data[index:])
+
+		switch {
+		case unicode.IsSpace(r):
+			inSpace = true
+		case inSpace:
+			return index, data[:index], nil
+		}
+
+		index += size
+	}
+
+	if atEOF && 0 < len(data) {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+func extract(text string, offset int64, fn func(Match)) {
+	const scheme string = "at://"
+
+	var index int = 0
+	for index < len(text) {
+		var found int = indexFold(text[index:], scheme)
+		if found < 0 {
+			return
+		}
+
+		var begin int = index + found
+		index = begin + len(scheme)
+
+		if 0 < begin && isSchemeByte(text[begin-1]) {
+			continue
+		}
+
+		var end int = begin + len(scheme)
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if isURIEnd(r) {
+				break
+			}
+			end += size
+		}
+
+		var uri string = trimProse(text[begin:end])
+
+		authority, collection, rkey, query, fragment, err := Split(uri)
+		if nil != err {
+			// Skip past the whole candidate (rather than just its "at://"),
+			// so that each byte of the text is scanned a bounded number of times, even for hostile input (such as "at://at://at://…").
+			index = end
+			continue
+		}
+
+		fn(Match{
+			Begin: offset + int64(begin),
+			End:   offset + int64(begin+len(uri)),
+
+			URI:        uri,
+			Authority:  authority,
+			Collection: collection,
+			RKey:       rkey,
+			Query:      query,
+			Fragment:   fragment,
+		})
+
+		index = begin + len(uri)
+	}
+}
+
+// indexFold is like strings.Index, but ASCII case-insensitive.
+// 'substr' must be lower-case.
+func indexFold(s string, substr string) int {
+	for index := 0; index+len(substr) <= len(s); index++ {
+		if strings.EqualFold(s[index:index+len(substr)], substr) {
+			return index
+		}
+	}
+	return -1
+}
+
+// isSchemeByte returns whether the byte could be part of a URI scheme.
+// An "at://" right after one of these is part of some other word (such as "chat://").
+func isSchemeByte(b byte) bool {
+	switch {
+	case 'A' <= b && b <= 'Z':
+	case 'a' <= b && b <= 'z':
+	case '0' <= b && b <= '9':
+	case '+' == b, '-' == b, '.' == b:
+	default:
+		return false
+	}
+	return true
+}
+
+// isURIEnd returns whether the rune ends an AT-URI in text.
+func isURIEnd(r rune) bool {
+	switch r {
+	case '<', '>', '"', '`':
+		return true
+	}
+	return unicode.IsSpace(r) || unicode.IsControl(r) || utf8.RuneError == r
+}
+
+// trimProse removes trailing punctuation that is probably part of the prose, rather than part of the AT-URI.
+//
+// The brackets are counted once, and the counts are kept up to date as closing brackets are removed,
+// so that trimProse is linear even for a long run of (for example) ")".
+func trimProse(uri string) string {
+	var opens  [3]int // '(', '[', '{'
+	var closes [3]int // ')', ']', '}'
+	for index := 0; index < len(uri); index++ {
+		switch uri[index] {
+		case '(':
+			opens[0]++
+		case '[':
+			opens[1]++
+		case '{':
+			opens[2]++
+		case ')':
+			closes[0]++
+		case ']':
+			closes[1]++
+		case '}':
+			closes[2]++
+		}
+	}
+
+	for {
+		if "" == uri {
+			return uri
+		}
+
+		{
+			r, size := utf8.DecodeLastRuneInString(uri)
+			if unicode.In(r, unicode.Pi, unicode.Pf) {
+				uri = uri[:len(uri)-size]
+				continue
+			}
+		}
+
+		var last byte = uri[len(uri)-1]
+
+		var bracket int = -1
+		switch last {
+		case '.', ',', ';', ':', '!', '?', '\'', '"':
+			uri = uri[:len(uri)-1]
+			continue
+		case ')':
+			bracket = 0
+		case ']':
+			bracket = 1
+		case '}':
+			bracket = 2
+		}
+
+		if 0 <= bracket && closes[bracket] > opens[bracket] {
+			closes[bracket]--
+			uri = uri[:len(uri)-1]
+			continue
+		}
+
+		return uri
+	}
+}
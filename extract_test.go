@@ -0,0 +1,231 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"fmt"
+	"strings"
+	"testing/iotest"
+	"time"
+
+	"github.com/reiver/go-aturi"
+)
+
+var extractTests = []struct{
+	Text string
+	Expected []string // "begin:end:uri"
+}{
+	{
+		Text: "",
+	},
+	{
+		Text: "nothing to see here",
+	},
+	{
+		Text: "at://example.com",
+		Expected: []string{
+			"0:16:at://example.com",
+		},
+	},
+	{
+		Text: "Please look at this (at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y).",
+		Expected: []string{
+			"21:91:at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+	},
+	{
+		Text: "Reported: at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y, and AT://example.com/app.bsky.actor.profile/self!",
+		Expected: []string{
+			"10:80:at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			"86:130:AT://example.com/app.bsky.actor.profile/self",
+		},
+	},
+	{
+		// byte offsets, not rune offsets.
+		Text: "😀 «at://example.com/app.bsky.feed.post/3jui7kd54zh2y» “at://example.com”",
+		Expected: []string{
+			"7:56:at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			"62:78:at://example.com",
+		},
+	},
+	{
+		Text: "<at://example.com/app.bsky.feed.post/3jui7kd54zh2y>",
+		Expected: []string{
+			"1:50:at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+	},
+	{
+		Text: "\"at://example.com/app.bsky.feed.post/3jui7kd54zh2y?once=1#path(/apple)\"",
+		Expected: []string{
+			"1:70:at://example.com/app.bsky.feed.post/3jui7kd54zh2y?once=1#path(/apple)",
+		},
+	},
+	{
+		Text: "chat://example.com is not one, and neither is at:// or at://@example.com or at://example.com/foorBar",
+	},
+	{
+		Text: "at://one.example\nat://two.example\tat://three.example",
+		Expected: []string{
+			"0:16:at://one.example",
+			"17:33:at://two.example",
+			"34:52:at://three.example",
+		},
+	},
+}
+
+func extractMatchString(match aturi.Match) string {
+	return fmt.Sprintf("%d:%d:%s", match.Begin, match.End, match.URI)
+}
+
+func TestExtract(t *testing.T) {
+
+	for testNumber, test := range extractTests {
+
+		var actual []string
+		for _, match := range aturi.Extract(test.Text) {
+			actual = append(actual, extractMatchString(match))
+
+			if expected, actual := match.URI, test.Text[match.Begin:match.End]; expected != actual {
+				t.Errorf("For test #%d, the byte offsets do not point at the AT-URI.", testNumber)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+			}
+		}
+
+		{
+			expected := fmt.Sprintf("%q", test.Expected)
+			actual   := fmt.Sprintf("%q", actual)
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual matches are not what was expected.", testNumber)
+				t.Logf("EXPECTED: %s", expected)
+				t.Logf("ACTUAL:   %s", actual)
+				t.Logf("TEXT: %q", test.Text)
+				continue
+			}
+		}
+	}
+}
+
+func TestExtractReader(t *testing.T) {
+
+	for testNumber, test := range extractTests {
+
+		var actual []string
+		err := aturi.ExtractReader(iotest.OneByteReader(strings.NewReader(test.Text)), func(match aturi.Match) {
+			actual = append(actual, extractMatchString(match))
+		})
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		{
+			expected := fmt.Sprintf("%q", test.Expected)
+			actual   := fmt.Sprintf("%q", actual)
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual matches are not what was expected.", testNumber)
+				t.Logf("EXPECTED: %s", expected)
+				t.Logf("ACTUAL:   %s", actual)
+				t.Logf("TEXT: %q", test.Text)
+				continue
+			}
+		}
+	}
+}
+
+func TestExtract_components(t *testing.T) {
+
+	matches := aturi.Extract("see at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1#path.")
+
+	if 1 != len(matches) {
+		t.Fatalf("Expected 1 match but actually got %d.", len(matches))
+	}
+
+	var match aturi.Match = matches[0]
+
+	expected := "did:plc:scewmn2pl3oz36mxme2b6czz|com.example.foorBar|3jui7kd54zh2y|once=1|path"
+	actual   := strings.Join([]string{match.Authority, match.Collection, match.RKey, match.Query, match.Fragment}, "|")
+
+	if expected != actual {
+		t.Errorf("The actual components are not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+}
+
+var extractAdversarialTexts = []struct{
+	Name string
+	Text string
+}{
+	{
+		Name: "repeated-scheme",
+		Text: strings.Repeat("at://", 16000),
+	},
+	{
+		Name: "closing-parens",
+		Text: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y" + strings.Repeat(")", 80000),
+	},
+	{
+		Name: "repeated-invalid",
+		Text: strings.Repeat("at://@example.com/", 8000),
+	},
+}
+
+// TestExtract_adversarial checks that hostile input does not make Extract (or ExtractReader) take quadratic time.
+func TestExtract_adversarial(t *testing.T) {
+
+	const limit time.Duration = 2 * time.Second
+
+	for _, test := range extractAdversarialTexts {
+
+		var start time.Time = time.Now()
+
+		aturi.Extract(test.Text)
+
+		err := aturi.ExtractReader(strings.NewReader(test.Text), func(aturi.Match) {})
+		if nil != err {
+			t.Errorf("For test %q, did not expect an error but actually got one.", test.Name)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if elapsed := time.Since(start); limit < elapsed {
+			t.Errorf("For test %q, Extract and ExtractReader took too long.", test.Name)
+			t.Logf("LIMIT:   %s", limit)
+			t.Logf("ELAPSED: %s", elapsed)
+			continue
+		}
+	}
+
+	{
+		matches := aturi.Extract(extractAdversarialTexts[1].Text)
+
+		if expected, actual := 1, len(matches); expected != actual {
+			t.Errorf("The actual number of matches is not what was expected.")
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			return
+		}
+		if expected, actual := "at://example.com/app.bsky.feed.post/3jui7kd54zh2y", matches[0].URI; expected != actual {
+			t.Errorf("The actual match is not what was expected.")
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			return
+		}
+	}
+}
+
+func BenchmarkExtract_adversarial(b *testing.B) {
+
+	for _, benchmark := range extractAdversarialTexts {
+		b.Run(benchmark.Name, func(b *testing.B) {
+			b.SetBytes(int64(len(benchmark.Text)))
+			for i := 0; i < b.N; i++ {
+				aturi.Extract(benchmark.Text)
+			}
+		})
+	}
+}
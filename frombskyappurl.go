@@ -0,0 +1,100 @@
+package aturi
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// FromBskyAppURL returns the AT-URI that a bsky.app web link refers to.
+//
+// The bsky.app links it understands are:
+//
+//	https://bsky.app/profile/{actor}                  → at://{actor}
+//	https://bsky.app/profile/{actor}/post/{rkey}      → at://{actor}/app.bsky.feed.post/{rkey}
+//	https://bsky.app/profile/{actor}/feed/{rkey}      → at://{actor}/app.bsky.feed.generator/{rkey}
+//	https://bsky.app/profile/{actor}/lists/{rkey}     → at://{actor}/app.bsky.graph.list/{rkey}
+//	https://bsky.app/starter-pack/{actor}/{rkey}      → at://{actor}/app.bsky.graph.starterpack/{rkey}
+//
+// Where {actor} is a handle or a DID.
+// The query and fragment of the bsky.app link are ignored.
+//
+// For example:
+//
+//	uri, err := aturi.FromBskyAppURL("https://bsky.app/profile/reiver.bsky.social/post/3jui7kd54zh2y")
+//	if nil != err {
+//		return err
+//	}
+//	
+//	// uri == "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y"
+func FromBskyAppURL(link string) (string, error) {
+	if "" == link {
+		return "", errEmptyURL
+	}
+
+	u, err := url.Parse(link)
+	if nil != err {
+		return "", erorr.Errorf("aturi: could not parse URL %q: %w", link, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+	default:
+		return "", erorr.Errorf("aturi: URL %q is not a bsky.app link because its scheme is not \"http\" or \"https\"", link)
+	}
+
+	{
+		const host string = "bsky.app"
+
+		if !strings.EqualFold(u.Host, host) {
+			return "", erorr.Errorf("aturi: URL %q is not a bsky.app link because its host is not %q", link, host)
+		}
+	}
+
+	var segments []string = strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var authority string
+	var collection string
+	var rkey string
+
+	switch {
+	case 2 == len(segments) && "profile" == segments[0]:
+		authority = segments[1]
+	case 4 == len(segments) && "profile" == segments[0]:
+		authority = segments[1]
+		rkey = segments[3]
+
+		switch segments[2] {
+		case "post":
+			collection = "app.bsky.feed.post"
+		case "feed":
+			collection = "app.bsky.feed.generator"
+		case "lists":
+			collection = "app.bsky.graph.list"
+		default:
+			return "", erorr.Errorf("aturi: bsky.app link %q has an unknown kind of record %q", link, segments[2])
+		}
+	case 3 == len(segments) && "starter-pack" == segments[0]:
+		authority = segments[1]
+		collection = "app.bsky.graph.starterpack"
+		rkey = segments[2]
+	default:
+		return "", erorr.Errorf("aturi: bsky.app link %q does not refer to a profile or a record", link)
+	}
+
+	if "" == authority {
+		return "", erorr.Errorf("aturi: bsky.app link %q has an empty actor", link)
+	}
+	if "" != collection && "" == rkey {
+		return "", erorr.Errorf("aturi: bsky.app link %q has an empty rkey", link)
+	}
+
+	var uri string = Join(authority, collection, rkey, "", "")
+
+	if err := Validate(uri); nil != err {
+		return "", erorr.Errorf("aturi: bsky.app link %q does not make a valid AT-URI: %w", link, err)
+	}
+
+	return uri, nil
+}
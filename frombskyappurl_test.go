@@ -0,0 +1,126 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestFromBskyAppURL(t *testing.T) {
+
+	tests := []struct{
+		URL string
+		Expected string
+	}{
+		{
+			URL:      "https://bsky.app/profile/reiver.bsky.social",
+			Expected: "at://reiver.bsky.social",
+		},
+		{
+			URL:      "https://bsky.app/profile/did:plc:scewmn2pl3oz36mxme2b6czz/",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+		},
+		{
+			URL:      "https://bsky.app/profile/reiver.bsky.social/post/3jui7kd54zh2y",
+			Expected: "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			URL:      "HTTPS://BSKY.APP/profile/did:plc:scewmn2pl3oz36mxme2b6czz/post/3jui7kd54zh2y?ref=share#top",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			URL:      "https://bsky.app/profile/did:plc:scewmn2pl3oz36mxme2b6czz/feed/whats-hot",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats-hot",
+		},
+		{
+			URL:      "https://bsky.app/profile/did:plc:scewmn2pl3oz36mxme2b6czz/lists/3k7zlbwhwvz2o",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o",
+		},
+		{
+			URL:      "https://bsky.app/starter-pack/reiver.bsky.social/3kpnnqzdgvn2v",
+			Expected: "at://reiver.bsky.social/app.bsky.graph.starterpack/3kpnnqzdgvn2v",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, err := aturi.FromBskyAppURL(test.URL)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URL: %q", test.URL)
+			continue
+		}
+
+		expected := test.Expected
+
+		if expected != actual {
+			t.Errorf("For test #%d, the actual AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URL: %q", test.URL)
+			continue
+		}
+	}
+}
+
+func TestFromBskyAppURL_fail(t *testing.T) {
+
+	tests := []struct{
+		URL string
+		ExpectedError string
+	}{
+		{
+			URL: "",
+			ExpectedError: `aturi: empty URL`,
+		},
+		{
+			URL: "ftp://bsky.app/profile/reiver.bsky.social",
+			ExpectedError: `aturi: URL "ftp://bsky.app/profile/reiver.bsky.social" is not a bsky.app link because its scheme is not "http" or "https"`,
+		},
+		{
+			URL: "https://example.com/profile/reiver.bsky.social",
+			ExpectedError: `aturi: URL "https://example.com/profile/reiver.bsky.social" is not a bsky.app link because its host is not "bsky.app"`,
+		},
+		{
+			URL: "https://bsky.app/",
+			ExpectedError: `aturi: bsky.app link "https://bsky.app/" does not refer to a profile or a record`,
+		},
+		{
+			URL: "https://bsky.app/profile/reiver.bsky.social/follows",
+			ExpectedError: `aturi: bsky.app link "https://bsky.app/profile/reiver.bsky.social/follows" does not refer to a profile or a record`,
+		},
+		{
+			URL: "https://bsky.app/profile/reiver.bsky.social/likes/3jui7kd54zh2y",
+			ExpectedError: `aturi: bsky.app link "https://bsky.app/profile/reiver.bsky.social/likes/3jui7kd54zh2y" has an unknown kind of record "likes"`,
+		},
+		{
+			URL: "https://bsky.app/profile/@reiver.bsky.social",
+			ExpectedError: `aturi: bsky.app link "https://bsky.app/profile/@reiver.bsky.social" does not make a valid AT-URI: aturi: URI "at://@reiver.bsky.social" may not have an "@" in its authority "@reiver.bsky.social"`,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		_, err := aturi.FromBskyAppURL(test.URL)
+
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URL: %q", test.URL)
+			continue
+		}
+
+		{
+			expected := test.ExpectedError
+			actual := err.Error()
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual 'error' is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+				t.Logf("URL: %q", test.URL)
+				continue
+			}
+		}
+	}
+}
@@ -3,6 +3,16 @@ module github.com/reiver/go-aturi
 go 1.22.4
 
 require (
+	github.com/fxamacker/cbor/v2 v2.9.2
 	github.com/reiver/go-erorr v0.0.0-20240801233437-8cbde6d1fa3f
 	github.com/reiver/go-nsid v0.0.0-20240827010024-502157631805
+	golang.org/x/net v0.35.0
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
 )
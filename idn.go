@@ -0,0 +1,224 @@
+package aturi
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/reiver/go-erorr"
+	"golang.org/x/net/idna"
+)
+
+// idnaProfile is the UTS-46 profile used for handles (the same one used for looking up domain names).
+var idnaProfile *idna.Profile = idna.Lookup
+
+// ToASCII returns the AT-URI with its handle authority in its ASCII (punycode, "xn--") form,
+// so that an AT-URI with a Unicode handle typed in by a user can be used like any other.
+//
+// For example:
+//
+//	uri, err := aturi.ToASCII("at://bücher.example/app.bsky.feed.post/3jui7kd54zh2y")
+//	
+//	// uri == "at://xn--bcher-kva.example/app.bsky.feed.post/3jui7kd54zh2y"
+//
+// The handle is converted with IDNA (UTS-46), which also makes it lower-case.
+// A DID authority is left as is.
+//
+// ToASCII returns an [*Error] if the handle cannot be converted, is not a valid handle once converted,
+// or has a label that mixes scripts (such as Latin and Cyrillic) or that is made up only of letters that look like Latin letters (such as Cyrillic "аррӏе"),
+// since those are how look-alike handles are made.
+func ToASCII(uri string) (string, error) {
+	authority, collection, rkey, query, fragment, err := Split(uri)
+	if nil != err {
+		return "", err
+	}
+
+	if strings.HasPrefix(authority, "did:") {
+		return uri, nil
+	}
+
+	ascii, err := idnaProfile.ToASCII(authority)
+	if nil != err {
+		return "", newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has a handle %q that is not a valid internationalized domain name: %w", uri, authority, err))
+	}
+
+	if err := validateHandle(ascii); nil != err {
+		return "", newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has a handle %q that is not valid: %w", uri, authority, err))
+	}
+
+	if err := checkHandleScripts(ascii); nil != err {
+		return "", newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has a handle %q that is not safe: %w", uri, authority, err))
+	}
+
+	return Join(ascii, collection, rkey, query, fragment), nil
+}
+
+// Display returns the AT-URI with its handle authority in its Unicode form, for showing to people.
+//
+// For example:
+//
+//	display, safe := aturi.Display("at://xn--bcher-kva.example/app.bsky.feed.post/3jui7kd54zh2y")
+//	
+//	// display == "at://bücher.example/app.bsky.feed.post/3jui7kd54zh2y"
+//	// safe    == true
+//
+// If the Unicode form of the handle is not safe to show (see [ToASCII] for what is not safe),
+// then Display returns the AT-URI as is (so the "xn--" form is shown) and false,
+// so that the UI can flag it.
+//
+// If the authority is a DID, Display returns the AT-URI as is and true.
+//
+// If [Split] returns an error for the AT-URI, Display returns the AT-URI as is and false,
+// since then it cannot tell whether what is shown is safe.
+func Display(uri string) (string, bool) {
+	authority, collection, rkey, query, fragment, err := Split(uri)
+	if nil != err {
+		return uri, false
+	}
+	if strings.HasPrefix(authority, "did:") {
+		return uri, true
+	}
+
+	ascii, err := idnaProfile.ToASCII(authority)
+	if nil != err {
+		return uri, false
+	}
+
+	if err := checkHandleScripts(ascii); nil != err {
+		return uri, false
+	}
+
+	unicodeForm, err := idnaProfile.ToUnicode(ascii)
+	if nil != err {
+		return uri, false
+	}
+
+	return Join(unicodeForm, collection, rkey, query, fragment), true
+}
+
+// checkHandleScripts returns an error if any label of the (ASCII form of the) handle is a mixed-script or whole-script-confusable label.
+func checkHandleScripts(ascii string) error {
+	for _, label := range strings.Split(ascii, ".") {
+		if !strings.HasPrefix(label, "xn--") {
+			continue
+		}
+
+		unicodeLabel, err := idnaProfile.ToUnicode(label)
+		if nil != err {
+			return err
+		}
+
+		if err := checkLabelScripts(unicodeLabel); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLabelScripts returns an error if the label mixes scripts, or if it is all letters that look like Latin letters.
+//
+// Mixing Han with Hiragana and Katakana (Japanese), with Hangul (Korean), or with Bopomofo (Chinese) is allowed.
+func checkLabelScripts(label string) error {
+	var scripts = map[string]struct{}{}
+	var confusable bool = true
+
+	for _, r := range label {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		var script string = runeScript(r)
+		if "" != script {
+			scripts[script] = struct{}{}
+		}
+
+		if !strings.ContainsRune(latinConfusables, r) {
+			confusable = false
+		}
+	}
+
+	switch len(scripts) {
+	case 0:
+		return nil
+	case 1:
+		if _, isLatin := scripts["Latin"]; !isLatin && confusable {
+			return erorr.Errorf("aturi: label %q is made up only of letters that look like Latin letters", label)
+		}
+		return nil
+	}
+
+	for _, allowed := range allowedScriptMixes {
+		var ok bool = true
+		for script := range scripts {
+			if _, found := allowed[script]; !found {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	var names []string
+	for script := range scripts {
+		names = append(names, script)
+	}
+	sort.Strings(names)
+	return erorr.Errorf("aturi: label %q mixes scripts (%s)", label, strings.Join(names, ", "))
+}
+
+// commonScripts is the scripts that runeScript checks first (in this order), since nearly every letter in a handle is in one of them.
+var commonScripts = []struct{
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin",      unicode.Latin},
+	{"Cyrillic",   unicode.Cyrillic},
+	{"Greek",      unicode.Greek},
+	{"Han",        unicode.Han},
+	{"Hiragana",   unicode.Hiragana},
+	{"Katakana",   unicode.Katakana},
+	{"Hangul",     unicode.Hangul},
+	{"Bopomofo",   unicode.Bopomofo},
+	{"Arabic",     unicode.Arabic},
+	{"Hebrew",     unicode.Hebrew},
+	{"Devanagari", unicode.Devanagari},
+	{"Thai",       unicode.Thai},
+}
+
+// runeScript returns the name of the script of the rune (such as "Latin" or "Cyrillic"),
+// or "" if it is "Common" or "Inherited" (i.e., used with any script).
+//
+// It checks commonScripts first, and only then every other script in unicode.Scripts (which is a map, so it is in no particular order).
+func runeScript(r rune) string {
+	for _, script := range commonScripts {
+		if unicode.Is(script.table, r) {
+			return script.name
+		}
+	}
+
+	for name, table := range unicode.Scripts {
+		if "Common" == name || "Inherited" == name {
+			continue
+		}
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// allowedScriptMixes is the mixes of scripts that are normal in a single label.
+var allowedScriptMixes = []map[string]struct{}{
+	{"Han": {}, "Hiragana": {}, "Katakana": {}},
+	{"Han": {}, "Hangul": {}},
+	{"Han": {}, "Bopomofo": {}},
+}
+
+// latinConfusables is the (lower-case) Cyrillic and Greek letters that look like Latin letters.
+const latinConfusables string =
+	"аеорсухіјѕԁһӏԛԝ" + // Cyrillic: a e o p c y x i j s d h l q w
+	"ҽүӕ" +             // Cyrillic: e y æ
+	"αοριτνκυχ"         // Greek: a o p i t v k u x
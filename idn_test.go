@@ -0,0 +1,203 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"errors"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestToASCII(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		Expected string
+	}{
+		{
+			URI:      "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			URI:      "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			URI:      "at://bücher.example/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: "at://xn--bcher-kva.example/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			URI:      "at://Bücher.Example/app.bsky.feed.post/3jui7kd54zh2y?once=1#frag",
+			Expected: "at://xn--bcher-kva.example/app.bsky.feed.post/3jui7kd54zh2y?once=1#frag",
+		},
+		{
+			URI:      "at://xn--bcher-kva.example",
+			Expected: "at://xn--bcher-kva.example",
+		},
+		{
+			URI:      "at://日本語ひらがな.example/app.bsky.feed.post",
+			Expected: "at://xn--v8j0cwa6g1563acvb2w6i.example/app.bsky.feed.post",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, err := aturi.ToASCII(test.URI)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected := test.Expected; expected != actual {
+			t.Errorf("For test #%d, the actual AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+	}
+}
+
+func TestToASCII_fail(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		ExpectedKind aturi.ErrorKind
+	}{
+		{
+			URI:          "",
+			ExpectedKind: aturi.ErrorKindEmpty,
+		},
+		{
+			URI:          "https://bücher.example/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+		{
+			// mixes Latin and Cyrillic
+			URI:          "at://pаypal.example/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			// all Cyrillic, but looks like "apple"
+			URI:          "at://аррӏе.example/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			// not a valid handle once converted (no TLD)
+			URI:          "at://bücher/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			URI:          "at://xn--zz.example/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, err := aturi.ToASCII(test.URI)
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("ACTUAL: %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		var aturiError *aturi.Error
+		if !errors.As(err, &aturiError) {
+			t.Errorf("For test #%d, expected the error to be an *aturi.Error but it was not.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected, actual := test.ExpectedKind, aturiError.Kind; expected != actual {
+			t.Errorf("For test #%d, the actual error kind is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("ERROR: %s", err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+	}
+}
+
+func TestDisplay(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		Expected string
+		ExpectedSafe bool
+	}{
+		{
+			URI:          "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected:     "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedSafe: true,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedSafe: true,
+		},
+		{
+			URI:          "at://xn--bcher-kva.example/app.bsky.feed.post/3jui7kd54zh2y?once=1#frag",
+			Expected:     "at://bücher.example/app.bsky.feed.post/3jui7kd54zh2y?once=1#frag",
+			ExpectedSafe: true,
+		},
+		{
+			URI:          "at://xn--v8j0cwa6g1563acvb2w6i.example",
+			Expected:     "at://日本語ひらがな.example",
+			ExpectedSafe: true,
+		},
+		{
+			// "pаypal" with a Cyrillic 'а'
+			URI:          "at://xn--pypal-4ve.example/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected:     "at://xn--pypal-4ve.example/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedSafe: false,
+		},
+		{
+			// "аррӏе" all in Cyrillic
+			URI:          "at://xn--80ak6aa92e.example/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected:     "at://xn--80ak6aa92e.example/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedSafe: false,
+		},
+		{
+			URI:          "not an at-uri",
+			Expected:     "not an at-uri",
+			ExpectedSafe: false,
+		},
+		{
+			URI:          "at://",
+			Expected:     "at://",
+			ExpectedSafe: false,
+		},
+		{
+			URI:          "at://xn--pypal-4ve.example/foorBar",
+			Expected:     "at://xn--pypal-4ve.example/foorBar",
+			ExpectedSafe: false,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, actualSafe := aturi.Display(test.URI)
+
+		if expected := test.Expected; expected != actual {
+			t.Errorf("For test #%d, the actual display form is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected := test.ExpectedSafe; expected != actualSafe {
+			t.Errorf("For test #%d, the actual 'safe' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %t", expected)
+			t.Logf("ACTUAL:   %t", actualSafe)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package aturi
+
+import (
+	"sync"
+
+	"github.com/reiver/go-erorr"
+)
+
+// Index stores values by record AT-URI (at://{authority}/{collection}/{rkey}).
+//
+// It is a 3-level trie, keyed by authority, then collection, then rkey,
+// so that it can efficiently iterate over all the records in a repo ([Index.RangeRepo]),
+// or all the records in a collection of a repo ([Index.RangeCollection]).
+//
+// Iteration is in key order.
+// Because TIDs use a base32 alphabet that sorts in the same order as the time they encode,
+// records with TID rkeys are iterated in time order (oldest first).
+//
+// Keys are compared exactly. To treat AT-URIs that only differ in the case of a handle the same, call [Normalize] first.
+//
+// Index is safe for many readers and a single writer at the same time.
+// The Range methods do not hold the lock while calling the function passed to them
+// (they copy out a bounded chunk of records at a time, and resume after the last key of it).
+// So, the function passed to a Range method may call any method of the same Index (including Set and Delete).
+// A record that is set or deleted while a Range method is running might or might not be seen by it;
+// every other record is seen exactly once.
+//
+// The zero value is an empty Index ready to use.
+type Index[V any] struct {
+	mutex   sync.RWMutex
+	repos   sortedMap[*indexRepo[V]]
+	length  int
+}
+
+type indexRepo[V any] struct {
+	collections sortedMap[*sortedMap[V]]
+}
+
+// IndexKey is the key of a record in an [Index].
+type IndexKey struct {
+	Authority  string
+	Collection string
+	RKey       string
+}
+
+// String returns the AT-URI of the key.
+func (receiver IndexKey) String() string {
+	return Join(receiver.Authority, receiver.Collection, receiver.RKey, "", "")
+}
+
+// indexKey returns the key for an AT-URI.
+func indexKey(uri string) (IndexKey, error) {
+	authority, collection, rkey, query, fragment, err := Split(uri)
+	if nil != err {
+		return IndexKey{}, err
+	}
+
+	if "" == collection || "" == rkey {
+		return IndexKey{}, erorr.Errorf("aturi: URI %q cannot be an index key because it is not the URI of a record (with a collection and an rkey)", uri)
+	}
+	if "" != query || "" != fragment {
+		return IndexKey{}, erorr.Errorf("aturi: URI %q cannot be an index key because it has a query or fragment", uri)
+	}
+
+	return IndexKey{
+		Authority:  authority,
+		Collection: collection,
+		RKey:       rkey,
+	}, nil
+}
+
+// Len returns the number of records in the index.
+func (receiver *Index[V]) Len() int {
+	receiver.mutex.RLock()
+	defer receiver.mutex.RUnlock()
+
+	return receiver.length
+}
+
+// Set stores the value for the record AT-URI, replacing any value already stored for it.
+func (receiver *Index[V]) Set(uri string, value V) error {
+	key, err := indexKey(uri)
+	if nil != err {
+		return err
+	}
+
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	repo, found := receiver.repos.get(key.Authority)
+	if !found {
+		repo = &indexRepo[V]{}
+		receiver.repos.set(key.Authority, repo)
+	}
+
+	records, found := repo.collections.get(key.Collection)
+	if !found {
+		records = &sortedMap[V]{}
+		repo.collections.set(key.Collection, records)
+	}
+
+	if _, found := records.get(key.RKey); !found {
+		receiver.length++
+	}
+	records.set(key.RKey, value)
+
+	return nil
+}
+
+// Get returns the value stored for the record AT-URI, if there is one.
+func (receiver *Index[V]) Get(uri string) (V, bool) {
+	var nada V
+
+	key, err := indexKey(uri)
+	if nil != err {
+		return nada, false
+	}
+
+	receiver.mutex.RLock()
+	defer receiver.mutex.RUnlock()
+
+	repo, found := receiver.repos.get(key.Authority)
+	if !found {
+		return nada, false
+	}
+
+	records, found := repo.collections.get(key.Collection)
+	if !found {
+		return nada, false
+	}
+
+	return records.get(key.RKey)
+}
+
+// Delete removes the value stored for the record AT-URI.
+// It returns whether there was a value to remove.
+func (receiver *Index[V]) Delete(uri string) bool {
+	key, err := indexKey(uri)
+	if nil != err {
+		return false
+	}
+
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	repo, found := receiver.repos.get(key.Authority)
+	if !found {
+		return false
+	}
+
+	records, found := repo.collections.get(key.Collection)
+	if !found {
+		return false
+	}
+
+	if !records.delete(key.RKey) {
+		return false
+	}
+	receiver.length--
+
+	if 0 == records.len() {
+		repo.collections.delete(key.Collection)
+	}
+	if 0 == repo.collections.len() {
+		receiver.repos.delete(key.Authority)
+	}
+
+	return true
+}
+
+// Range calls fn for every record in the index, in key order.
+// If fn returns false, Range stops.
+func (receiver *Index[V]) Range(fn func(IndexKey, V) bool) {
+	if nil == fn {
+		return
+	}
+
+	receiver.rangeChunks(IndexKey{}, func(from IndexKey, entries []indexEntry[V]) []indexEntry[V] {
+		receiver.repos.eachFrom(from.Authority, func(authority string, repo *indexRepo[V]) bool {
+			if authority != from.Authority {
+				from = IndexKey{}
+			}
+
+			entries = appendRepo(entries, authority, repo, from.Collection, from.RKey)
+			return len(entries) < indexChunkSize
+		})
+		return entries
+	}, fn)
+}
+
+// RangeRepo calls fn for every record in the repo with the authority (a DID or handle), in key order.
+// If fn returns false, RangeRepo stops.
+func (receiver *Index[V]) RangeRepo(authority string, fn func(IndexKey, V) bool) {
+	if nil == fn {
+		return
+	}
+
+	receiver.rangeChunks(IndexKey{Authority: authority}, func(from IndexKey, entries []indexEntry[V]) []indexEntry[V] {
+		repo, found := receiver.repos.get(authority)
+		if !found {
+			return entries
+		}
+
+		return appendRepo(entries, authority, repo, from.Collection, from.RKey)
+	}, fn)
+}
+
+// RangeCollection calls fn for every record in the collection of the repo with the authority (a DID or handle), in rkey order.
+// If fn returns false, RangeCollection stops.
+func (receiver *Index[V]) RangeCollection(authority string, collection string, fn func(IndexKey, V) bool) {
+	if nil == fn {
+		return
+	}
+
+	receiver.rangeChunks(IndexKey{Authority: authority, Collection: collection}, func(from IndexKey, entries []indexEntry[V]) []indexEntry[V] {
+		repo, found := receiver.repos.get(authority)
+		if !found {
+			return entries
+		}
+
+		records, found := repo.collections.get(collection)
+		if !found {
+			return entries
+		}
+
+		return appendCollection(entries, authority, collection, records, from.RKey)
+	}, fn)
+}
+
+// indexChunkSize is the most records the Range methods copy out of an Index at a time.
+const indexChunkSize int = 256
+
+// indexEntry is a record copied out of an Index.
+//
+// The Range methods copy a chunk of records while holding the lock, and then call fn on them (without holding the lock),
+// so that fn may call any method of the Index.
+type indexEntry[V any] struct {
+	key   IndexKey
+	value V
+}
+
+// rangeChunks calls fn for the records that fill returns, chunk by chunk, until fn returns false or there are no more records.
+//
+// fill is called while holding the read lock.
+// It appends (up to indexChunkSize of) the records from the key (inclusive) on, in key order.
+// Each chunk after the first starts right after the last key of the chunk before it,
+// so the memory used stays bounded however many records there are.
+func (receiver *Index[V]) rangeChunks(from IndexKey, fill func(IndexKey, []indexEntry[V]) []indexEntry[V], fn func(IndexKey, V) bool) {
+	var entries []indexEntry[V]
+
+	for {
+		func() {
+			receiver.mutex.RLock()
+			defer receiver.mutex.RUnlock()
+
+			entries = fill(from, entries[:0])
+		}()
+
+		for _, entry := range entries {
+			if !fn(entry.key, entry.value) {
+				return
+			}
+		}
+
+		if len(entries) < indexChunkSize {
+			return
+		}
+
+		// "\x00" is the smallest byte, so this is the smallest key after the last one.
+		from = entries[len(entries)-1].key
+		from.RKey += "\x00"
+	}
+}
+
+// appendRepo appends the records of the repo from the collection and rkey (inclusive) on, until there are indexChunkSize entries.
+func appendRepo[V any](entries []indexEntry[V], authority string, repo *indexRepo[V], fromCollection string, fromRKey string) []indexEntry[V] {
+	repo.collections.eachFrom(fromCollection, func(collection string, records *sortedMap[V]) bool {
+		if collection != fromCollection {
+			fromRKey = ""
+		}
+
+		entries = appendCollection(entries, authority, collection, records, fromRKey)
+		return len(entries) < indexChunkSize
+	})
+	return entries
+}
+
+// appendCollection appends the records of the collection from the rkey (inclusive) on, until there are indexChunkSize entries.
+func appendCollection[V any](entries []indexEntry[V], authority string, collection string, records *sortedMap[V], fromRKey string) []indexEntry[V] {
+	records.eachFrom(fromRKey, func(rkey string, value V) bool {
+		entries = append(entries, indexEntry[V]{key: IndexKey{Authority: authority, Collection: collection, RKey: rkey}, value: value})
+		return len(entries) < indexChunkSize
+	})
+	return entries
+}
@@ -0,0 +1,450 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/reiver/go-aturi"
+)
+
+// tid returns the TID (timestamp identifier) for a time in microseconds since the Unix epoch.
+func tid(microseconds uint64) string {
+	const alphabet string = "234567abcdefghijklmnopqrstuvwxyz"
+
+	var value uint64 = (microseconds << 10) & 0x7FFFFFFFFFFFFFFF
+
+	var buffer [13]byte
+	for index := len(buffer)-1; 0 <= index; index-- {
+		buffer[index] = alphabet[value & 0x1F]
+		value >>= 5
+	}
+
+	return string(buffer[:])
+}
+
+func indexKeys(index *aturi.Index[int], rangeFunc func(*aturi.Index[int], func(aturi.IndexKey, int) bool)) []string {
+	var keys []string
+
+	rangeFunc(index, func(key aturi.IndexKey, value int) bool {
+		keys = append(keys, fmt.Sprintf("%s=%d", key, value))
+		return true
+	})
+
+	return keys
+}
+
+func TestIndex(t *testing.T) {
+
+	const alice string = "did:plc:scewmn2pl3oz36mxme2b6czz"
+	const bob   string = "did:plc:ewvi7nxzyoun6zhxrhs64oiz"
+
+	var index aturi.Index[int]
+
+	var (
+		older  string = tid(1700000000000000)
+		middle string = tid(1700000000000001)
+		newer  string = tid(1800000000000000)
+	)
+
+	// inserted out of (time) order on purpose.
+	for value, uri := range []string{
+		"at://" + alice + "/app.bsky.feed.post/"    + newer,
+		"at://" + bob   + "/app.bsky.feed.post/"    + older,
+		"at://" + alice + "/app.bsky.feed.post/"    + older,
+		"at://" + alice + "/app.bsky.actor.profile/self",
+		"at://" + alice + "/app.bsky.feed.post/"    + middle,
+		"at://" + alice + "/app.bsky.graph.follow/" + older,
+	} {
+		if err := index.Set(uri, value); nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+	}
+
+	if expected, actual := 6, index.Len(); expected != actual {
+		t.Errorf("The actual 'length' is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+
+	{
+		value, found := index.Get("at://" + alice + "/app.bsky.feed.post/" + middle)
+		if !found || 4 != value {
+			t.Errorf("The actual value from Get() is not what was expected: value=%d found=%t", value, found)
+		}
+
+		_, found = index.Get("at://" + bob + "/app.bsky.feed.post/" + middle)
+		if found {
+			t.Errorf("Expected Get() to not find a value, but it actually did.")
+		}
+	}
+
+	{
+		expected := fmt.Sprintf("%q", []string{
+			"at://" + alice + "/app.bsky.feed.post/" + older  + "=2",
+			"at://" + alice + "/app.bsky.feed.post/" + middle + "=4",
+			"at://" + alice + "/app.bsky.feed.post/" + newer  + "=0",
+		})
+		actual := fmt.Sprintf("%q", indexKeys(&index, func(index *aturi.Index[int], fn func(aturi.IndexKey, int) bool) {
+			index.RangeCollection(alice, "app.bsky.feed.post", fn)
+		}))
+
+		if expected != actual {
+			t.Errorf("The actual records from RangeCollection() are not what was expected (in time order).")
+			t.Logf("EXPECTED: %s", expected)
+			t.Logf("ACTUAL:   %s", actual)
+		}
+	}
+
+	{
+		expected := fmt.Sprintf("%q", []string{
+			"at://" + alice + "/app.bsky.actor.profile/self=3",
+			"at://" + alice + "/app.bsky.feed.post/" + older  + "=2",
+			"at://" + alice + "/app.bsky.feed.post/" + middle + "=4",
+			"at://" + alice + "/app.bsky.feed.post/" + newer  + "=0",
+			"at://" + alice + "/app.bsky.graph.follow/" + older + "=5",
+		})
+		actual := fmt.Sprintf("%q", indexKeys(&index, func(index *aturi.Index[int], fn func(aturi.IndexKey, int) bool) {
+			index.RangeRepo(alice, fn)
+		}))
+
+		if expected != actual {
+			t.Errorf("The actual records from RangeRepo() are not what was expected.")
+			t.Logf("EXPECTED: %s", expected)
+			t.Logf("ACTUAL:   %s", actual)
+		}
+	}
+
+	{
+		if !index.Delete("at://" + bob + "/app.bsky.feed.post/" + older) {
+			t.Errorf("Expected Delete() to delete a value, but it actually did not.")
+		}
+		if index.Delete("at://" + bob + "/app.bsky.feed.post/" + older) {
+			t.Errorf("Expected a 2nd Delete() to not delete a value, but it actually did.")
+		}
+
+		var count int
+		index.RangeRepo(bob, func(aturi.IndexKey, int) bool {
+			count++
+			return true
+		})
+		if 0 != count {
+			t.Errorf("Expected no records in the deleted repo, but actually there are %d.", count)
+		}
+
+		if expected, actual := 5, index.Len(); expected != actual {
+			t.Errorf("The actual 'length' after Delete() is not what was expected.")
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+		}
+	}
+
+	{
+		var count int
+		index.Range(func(aturi.IndexKey, int) bool {
+			count++
+			return count < 2
+		})
+		if 2 != count {
+			t.Errorf("Expected Range() to stop after 2 records, but it actually called fn %d times.", count)
+		}
+	}
+}
+
+func TestIndex_Set_fail(t *testing.T) {
+
+	var index aturi.Index[int]
+
+	for testNumber, uri := range []string{
+		"",
+		"at://example.com",
+		"at://example.com/app.bsky.feed.post",
+		"at://example.com/app.bsky.feed.post/3jui7kd54zh2y?once=1",
+		"at://example.com/app.bsky.feed.post/3jui7kd54zh2y#path",
+	} {
+		if err := index.Set(uri, 1); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", uri)
+		}
+	}
+}
+
+func TestIndex_concurrent(t *testing.T) {
+
+	var index aturi.Index[int]
+
+	const numRecords int = 1000
+
+	var waitGroup sync.WaitGroup
+
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+
+		for value := 0; value < numRecords; value++ {
+			index.Set(fmt.Sprintf("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/%s", tid(uint64(value))), value)
+			if 0 == value % 3 {
+				index.Delete(fmt.Sprintf("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/%s", tid(uint64(value))))
+			}
+		}
+	}()
+
+	for reader := 0; reader < 4; reader++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for i := 0; i < 100; i++ {
+				var previous string
+				index.RangeCollection("did:plc:scewmn2pl3oz36mxme2b6czz", "app.bsky.feed.post", func(key aturi.IndexKey, value int) bool {
+					if key.RKey <= previous {
+						t.Errorf("Records are out of order: %q came after %q", key.RKey, previous)
+						return false
+					}
+					previous = key.RKey
+					return true
+				})
+				index.Get("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/" + tid(uint64(i)))
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	if expected, actual := numRecords - (numRecords+2)/3, index.Len(); expected != actual {
+		t.Errorf("The actual 'length' is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+}
+
+func TestIndex_random(t *testing.T) {
+
+	var index aturi.Index[int]
+	var expected = map[string]int{}
+
+	var random = rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20000; i++ {
+		var rkey string = tid(uint64(random.Intn(2000)))
+		var uri string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/" + rkey
+
+		switch random.Intn(3) {
+		case 0:
+			_, found := expected[rkey]
+			if actual := index.Delete(uri); found != actual {
+				t.Fatalf("For step #%d, the actual result of Delete(%q) is not what was expected: expected %t but actually got %t", i, uri, found, actual)
+			}
+			delete(expected, rkey)
+		default:
+			if err := index.Set(uri, i); nil != err {
+				t.Fatalf("For step #%d, did not expect an error but actually got one: (%T) %s", i, err, err)
+			}
+			expected[rkey] = i
+		}
+	}
+
+	if expected, actual := len(expected), index.Len(); expected != actual {
+		t.Errorf("The actual 'length' is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+
+	var rkeys []string
+	for rkey := range expected {
+		rkeys = append(rkeys, rkey)
+	}
+	sort.Strings(rkeys)
+
+	var actual []string
+	index.RangeCollection("did:plc:scewmn2pl3oz36mxme2b6czz", "app.bsky.feed.post", func(key aturi.IndexKey, value int) bool {
+		if expected[key.RKey] != value {
+			t.Errorf("The actual value for rkey %q is not what was expected: expected %d but actually got %d", key.RKey, expected[key.RKey], value)
+		}
+		actual = append(actual, key.RKey)
+		return true
+	})
+
+	if !slices.Equal(rkeys, actual) {
+		t.Errorf("The actual rkeys are not what was expected.")
+		t.Logf("EXPECTED: (%d) %q", len(rkeys), rkeys)
+		t.Logf("ACTUAL:   (%d) %q", len(actual), actual)
+	}
+}
+
+func BenchmarkIndex_Set(b *testing.B) {
+
+	var index aturi.Index[int]
+
+	var uris []string
+	for i := 0; i < 100000; i++ {
+		uris = append(uris, "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/"+tid(uint64((i*7919)%100000)))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var uri string = uris[i%len(uris)]
+		if err := index.Set(uri, i); nil != err {
+			b.Fatalf("did not expect an error but actually got one: (%T) %s", err, err)
+		}
+		if 0 == i%2 {
+			index.Delete(uri)
+		}
+	}
+}
+
+func TestIndex_Range_reentrant(t *testing.T) {
+
+	var index aturi.Index[int]
+
+	for value := 0; value < 10; value++ {
+		if err := index.Set("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/"+tid(uint64(value)), value); nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+	}
+
+	var done = make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		var count int
+		index.Range(func(key aturi.IndexKey, value int) bool {
+			count++
+
+			if _, found := index.Get(key.String()); !found {
+				t.Errorf("Expected Get(%q) to find the record, but it actually did not.", key)
+			}
+			index.RangeRepo(key.Authority, func(aturi.IndexKey, int) bool { return false })
+			index.Delete(key.String())
+			if err := index.Set(key.String()+"x", value); nil != err {
+				t.Errorf("Did not expect an error but actually got one: (%T) %s", err, err)
+			}
+			return true
+		})
+
+		if expected, actual := 10, count; expected != actual {
+			t.Errorf("The actual number of records ranged over is not what was expected.")
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Range deadlocked when its function called methods of the same Index.")
+	}
+
+	if expected, actual := 10, index.Len(); expected != actual {
+		t.Errorf("The actual 'length' is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+}
+
+func TestIndex_Range_chunks(t *testing.T) {
+
+	var authorities = []string{
+		"did:plc:ewvi7nxzyoun6zhxrhs64oiz",
+		"did:plc:scewmn2pl3oz36mxme2b6czz",
+		"example.com",
+	}
+	var collections = []string{
+		"app.bsky.feed.like",
+		"app.bsky.feed.post",
+	}
+
+	var index aturi.Index[int]
+	var expected []string
+
+	// more records than fit in a chunk, in every collection, and a collection with exactly 1 record.
+	for _, authority := range authorities {
+		for _, collection := range collections {
+			for value := 0; value < 300; value++ {
+				var uri string = "at://" + authority + "/" + collection + "/" + tid(uint64(value))
+				if err := index.Set(uri, value); nil != err {
+					t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+				}
+				expected = append(expected, uri)
+			}
+		}
+		var uri string = "at://" + authority + "/app.bsky.actor.profile/self"
+		if err := index.Set(uri, 0); nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+		expected = append(expected, uri)
+	}
+	sort.Strings(expected)
+
+	{
+		var actual []string
+
+		// Deleting every record as it is seen must not make Range skip (or repeat) any of the others.
+		index.Range(func(key aturi.IndexKey, value int) bool {
+			actual = append(actual, key.String())
+			index.Delete(key.String())
+			return true
+		})
+
+		if !slices.Equal(expected, actual) {
+			t.Errorf("The actual records from Range() are not what was expected.")
+			t.Logf("EXPECTED: (%d) %q", len(expected), expected)
+			t.Logf("ACTUAL:   (%d) %q", len(actual), actual)
+		}
+	}
+
+	if expected, actual := 0, index.Len(); expected != actual {
+		t.Errorf("The actual 'length' is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+
+	for _, uri := range expected {
+		if err := index.Set(uri, 0); nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+	}
+
+	for _, authority := range authorities {
+		var count int
+		index.RangeRepo(authority, func(key aturi.IndexKey, value int) bool {
+			if authority != key.Authority {
+				t.Errorf("Expected RangeRepo(%q) to only call fn for records of that repo, but it actually called it for %q.", authority, key)
+			}
+			count++
+			return true
+		})
+
+		if expected, actual := 2*300+1, count; expected != actual {
+			t.Errorf("The actual number of records from RangeRepo(%q) is not what was expected.", authority)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+		}
+
+		for _, collection := range collections {
+			var actual []string
+			index.RangeCollection(authority, collection, func(key aturi.IndexKey, value int) bool {
+				actual = append(actual, key.RKey)
+				return true
+			})
+
+			var rkeys []string
+			for value := 0; value < 300; value++ {
+				rkeys = append(rkeys, tid(uint64(value)))
+			}
+
+			if !slices.Equal(rkeys, actual) {
+				t.Errorf("The actual rkeys from RangeCollection(%q, %q) are not what was expected.", authority, collection)
+				t.Logf("EXPECTED: (%d) %q", len(rkeys), rkeys)
+				t.Logf("ACTUAL:   (%d) %q", len(actual), actual)
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Command gencollections generates the Go code for the well-known collections (collections.go in package aturi)
+// from Lexicon JSON files.
+//
+// Usage:
+//
+//	go run ./internal/gencollections -dir lexicons [-dir <another directory>] -out collections.go
+//
+// Each "record" Lexicon found (recursively) in the directories becomes:
+// a [Collection] marker type, a typed constant with its NSID, and an entry in the registry that CollectionInfo looks things up in.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/reiver/go-nsid"
+)
+
+// dirs is the "-dir" flag, which may be given more than once.
+type dirs []string
+
+func (receiver *dirs) String() string {
+	return strings.Join(*receiver, ",")
+}
+
+func (receiver *dirs) Set(value string) error {
+	*receiver = append(*receiver, value)
+	return nil
+}
+
+// document is the part of a Lexicon JSON file that is needed here.
+type document struct {
+	ID   string `json:"id"`
+	Defs map[string]struct{
+		Type        string `json:"type"`
+		Key         string `json:"key"`
+		Description string `json:"description"`
+	} `json:"defs"`
+}
+
+// collection is what is generated for one record Lexicon.
+type collection struct {
+	Name        string // the Go name, such as "FeedPost"
+	NSID        string // such as "app.bsky.feed.post"
+	RecordKey   string // such as "tid"
+	Description string
+}
+
+func main() {
+	var directories dirs
+	flag.Var(&directories, "dir", "directory to read Lexicon JSON files from (may be given more than once)")
+	var out *string = flag.String("out", "collections.go", "file to write the generated Go code to")
+	flag.Parse()
+
+	if 0 == len(directories) {
+		fmt.Fprintln(os.Stderr, "gencollections: at least one -dir is required")
+		os.Exit(2)
+	}
+
+	collections, err := load(directories)
+	if nil != err {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	code, err := generate(collections)
+	if nil != err {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*out, code, 0644); nil != err {
+		fmt.Fprintln(os.Stderr, "gencollections:", err)
+		os.Exit(1)
+	}
+}
+
+// load returns the record Lexicons in the directories, sorted by NSID.
+func load(directories []string) ([]collection, error) {
+	var collections []collection
+	var names = map[string]string{}
+	var nsids = map[string]string{}
+
+	for _, directory := range directories {
+		err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+			if nil != err {
+				return err
+			}
+			if entry.IsDir() || ".json" != filepath.Ext(path) {
+				return nil
+			}
+
+			data, err := os.ReadFile(path)
+			if nil != err {
+				return err
+			}
+
+			var doc document
+			if err := json.Unmarshal(data, &doc); nil != err {
+				return fmt.Errorf("gencollections: could not parse Lexicon file %q: %w", path, err)
+			}
+
+			main, found := doc.Defs["main"]
+			if !found || "record" != main.Type {
+				return nil
+			}
+
+			if err := nsid.Validate(doc.ID); nil != err {
+				return fmt.Errorf("gencollections: Lexicon file %q has an id %q that is not a valid NSID: %w", path, doc.ID, err)
+			}
+			if err := checkRecordKey(main.Key); nil != err {
+				return fmt.Errorf("gencollections: Lexicon file %q: %w", path, err)
+			}
+
+			var name string = goName(doc.ID)
+
+			if other, found := nsids[doc.ID]; found {
+				return fmt.Errorf("gencollections: Lexicon files %q and %q both have the id %q", other, path, doc.ID)
+			}
+			if other, found := names[name]; found {
+				return fmt.Errorf("gencollections: Lexicon files %q and %q would both be named %q", other, path, name)
+			}
+			nsids[doc.ID] = path
+			names[name] = path
+
+			collections = append(collections, collection{
+				Name:        name,
+				NSID:        doc.ID,
+				RecordKey:   main.Key,
+				Description: strings.Join(strings.Fields(main.Description), " "),
+			})
+			return nil
+		})
+		if nil != err {
+			return nil, err
+		}
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].NSID < collections[j].NSID
+	})
+
+	return collections, nil
+}
+
+// checkRecordKey returns an error if the record-key type is not one that the Lexicon language has.
+func checkRecordKey(key string) error {
+	switch {
+	case "tid" == key, "nsid" == key, "any" == key:
+		return nil
+	case strings.HasPrefix(key, "literal:") && len("literal:") < len(key):
+		return nil
+	default:
+		return fmt.Errorf("record has an unknown record-key type %q", key)
+	}
+}
+
+// goName returns the Go name for a collection, from the last 2 segments of its NSID.
+// For example, "app.bsky.feed.post" → "FeedPost".
+func goName(id string) string {
+	var segments []string = strings.Split(id, ".")
+	if 2 < len(segments) {
+		segments = segments[len(segments)-2:]
+	}
+
+	var builder strings.Builder
+	for _, segment := range segments {
+		builder.WriteString(strings.ToUpper(segment[:1]))
+		builder.WriteString(segment[1:])
+	}
+	return builder.String()
+}
+
+func generate(collections []collection) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := codeTemplate.Execute(&buffer, collections); nil != err {
+		return nil, fmt.Errorf("gencollections: %w", err)
+	}
+
+	code, err := format.Source(buffer.Bytes())
+	if nil != err {
+		return nil, fmt.Errorf("gencollections: generated code does not compile: %w", err)
+	}
+
+	return code, nil
+}
+
+var codeTemplate = template.Must(template.New("collections.go").Parse(`// Code generated by gencollections from Lexicon JSON files; DO NOT EDIT.
+
+package aturi
+
+// These are the [Collection] marker types for the well-known record types.
+type (
+{{- range .}}
+	{{.Name}} struct{} // {{.NSID}}
+{{- end}}
+)
+{{range .}}
+func ({{.Name}}) NSID() string { return string(Collection{{.Name}}) }
+{{- end}}
+
+// These are the NSIDs of the well-known record types.
+const (
+{{- range .}}
+	// Collection{{.Name}} is the collection for records of type {{.NSID}} (record-key: {{.RecordKey}}).
+	// {{printf "%s" .Description}}
+	Collection{{.Name}} CollectionNSID = {{printf "%q" .NSID}}
+{{end -}}
+)
+
+// collectionInfos is the registry of well-known collections that [CollectionInfo] looks things up in.
+var collectionInfos = map[string]CollectionMetadata{
+{{- range .}}
+	{{printf "%q" .NSID}}: {
+		NSID:        Collection{{.Name}},
+		RecordKey:   {{printf "%q" .RecordKey}},
+		Description: {{printf "%q" .Description}},
+	},
+{{- end}}
+}
+`))
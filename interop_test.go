@@ -0,0 +1,344 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+// interopTests lists the AT-protocol syntax interop test vectors (in "testdata/syntax").
+//
+// The handle, DID, NSID, and record-key test vectors are not AT-URIs,
+// so 'Wrap' puts each of them into the part of an AT-URI where it belongs.
+//
+// 'Known' lists test vectors (with the reason) where aturi.ValidateStrict (and so also aturi.Validate) is known to disagree.
+//
+// 'Lenient' lists the invalid test vectors (with the reason) that the lenient aturi.Validate accepts, even though aturi.ValidateStrict rejects them.
+var interopTests = []struct{
+	File string
+	Valid bool
+	Wrap func(string) string
+	Known map[string]string
+	Lenient map[string]string
+}{
+	{
+		File: "aturi_syntax_valid.txt",
+		Valid: true,
+		Wrap: func(value string) string { return value },
+	},
+	{
+		File: "aturi_syntax_invalid.txt",
+		Valid: false,
+		Wrap: func(value string) string { return value },
+		Lenient: map[string]string{
+			"AT://did:plc:asdf123":                                           interopLenientScheme,
+			"at://did:plc:asdf123 ":                                          interopLenientAuthority,
+			"at://did:plc:asdf123/com.atproto.feed.post# ":                   interopLenientQueryFragment,
+			"at://did:plc:asdf123/com.atproto.feed.post#/ ":                  interopLenientQueryFragment,
+			"at://did:plc:asdf123/com.atproto.feed.post#/frag ":              interopLenientQueryFragment,
+			"at://did:plc:asdf123/com.atproto.feed.post#fr ag":               interopLenientQueryFragment,
+			"at://name":                                                      interopLenientAuthority,
+			"at://name.0":                                                    interopLenientAuthority,
+			"at://diD:plc:asdf123":                                           interopLenientAuthority,
+			"at://DID:plc:asdf123":                                           interopLenientAuthority,
+			"at://user.bsky.123":                                             interopLenientAuthority,
+			"at://bsky":                                                      interopLenientAuthority,
+			"at://did:plc:":                                                  interopLenientAuthority,
+			"at://frag":                                                      interopLenientAuthority,
+			"at://user.bsky.social//":                                        interopLenientPath,
+			"at://user.bsky.social//com.atproto.feed.post":                   interopLenientPath,
+			"at://user.bsky.social/com.atproto.feed.post//":                  interopLenientPath,
+			"at://did:plc:asdf123/com.atproto.feed.post/asdf123/more/more',": interopLenientPath,
+			"at://did:plc:asdf123/":                                          interopLenientPath,
+			"at://user.bsky.social/":                                         interopLenientPath,
+			"at://did:plc:asdf123/com.atproto.feed.post/":                    interopLenientPath,
+			"at://did:plc:asdf123/com.atproto.feed.post/record/":             interopLenientPath,
+			"at://did:plc:asdf123/com.atproto.feed.post/record/#/frag":       interopLenientPath,
+			"at://did:plc:asdf123/com.atproto.feed.post/asdf123/asdf":        interopLenientPath,
+			"at://did:plc:asdf123#":                                          interopLenientQueryFragment,
+			"at://did:plc:asdf123##":                                         interopLenientQueryFragment,
+			"at://did:plc:asdf123#/asdf#/asdf":                               interopLenientQueryFragment,
+			"at://did:plc:asdf123/com.atproto.feed.post/%23":                 interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/$@!*)(:,;~.sdf123":   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/~'sdf123\")":         interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/$":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/@":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/!":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/*":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/(":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/,":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/;":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/abc%30123":           interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/%30":                 interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/%3":                  interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/%":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/%zz":                 interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/%%%":                 interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/.":                   interopLenientRecordKey,
+			"at://did:plc:asdf123/com.atproto.feed.post/..":                  interopLenientRecordKey,
+		},
+	},
+
+
+
+	{
+		File: "handle_syntax_valid.txt",
+		Valid: true,
+		Wrap: func(value string) string { return "at://" + value },
+	},
+	{
+		File: "handle_syntax_invalid.txt",
+		Valid: false,
+		Wrap: func(value string) string { return "at://" + value },
+		Lenient: map[string]string{
+			"did:thing.test":                                                              interopLenientAuthority,
+			"did:thing":                                                                   interopLenientAuthority,
+			"john-.test":                                                                  interopLenientAuthority,
+			"john.0":                                                                      interopLenientAuthority,
+			"john.-":                                                                      interopLenientAuthority,
+			"xn--bcher-.tld":                                                              interopLenientAuthority,
+			"john..test":                                                                  interopLenientAuthority,
+			"jo_hn.test":                                                                  interopLenientAuthority,
+			"-john.test":                                                                  interopLenientAuthority,
+			".john.test":                                                                  interopLenientAuthority,
+			"jo!hn.test":                                                                  interopLenientAuthority,
+			"jo%hn.test":                                                                  interopLenientAuthority,
+			"jo&hn.test":                                                                  interopLenientAuthority,
+			"jo*hn.test":                                                                  interopLenientAuthority,
+			"jo|hn.test":                                                                  interopLenientAuthority,
+			"jo:hn.test":                                                                  interopLenientAuthority,
+			"john💩.test":                                                                  interopLenientAuthority,
+			"bücher.test":                                                                 interopLenientAuthority,
+			"john .test":                                                                  interopLenientAuthority,
+			"john.test.":                                                                  interopLenientAuthority,
+			"john":                                                                        interopLenientAuthority,
+			"john.":                                                                       interopLenientAuthority,
+			".john":                                                                       interopLenientAuthority,
+			" john.test":                                                                  interopLenientAuthority,
+			"john.test ":                                                                  interopLenientAuthority,
+			"joh-.test":                                                                   interopLenientAuthority,
+			"john.-est":                                                                   interopLenientAuthority,
+			"john.tes-":                                                                   interopLenientAuthority,
+			"shoooort" + strings.Repeat(".l"+strings.Repeat("o", 26)+"ng", 9) + ".test": interopLenientAuthority,
+			"short.oooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooo.test": interopLenientAuthority,
+			"org":                                                                         interopLenientAuthority,
+			"ai":                                                                          interopLenientAuthority,
+			"gg":                                                                          interopLenientAuthority,
+			"io":                                                                          interopLenientAuthority,
+			"cn.8":                                                                        interopLenientAuthority,
+			"thing.0aa":                                                                   interopLenientAuthority,
+			"127.0.0.1":                                                                   interopLenientAuthority,
+			"192.168.0.142":                                                               interopLenientAuthority,
+			"fe80::7325:8a97:c100:94b":                                                    interopLenientAuthority,
+			"2600:3c03::f03c:9100:feb0:af1f":                                              interopLenientAuthority,
+			"-notvalid.at-all":                                                            interopLenientAuthority,
+			"-thing.com":                                                                  interopLenientAuthority,
+			"www.masełkowski.pl.com":                                                      interopLenientAuthority,
+		},
+	},
+
+
+
+	{
+		File: "did_syntax_valid.txt",
+		Valid: true,
+		Wrap: func(value string) string { return "at://" + value },
+	},
+	{
+		File: "did_syntax_invalid.txt",
+		Valid: false,
+		Wrap: func(value string) string { return "at://" + value },
+		Known: map[string]string{
+			"did.method.val": "not a valid DID, but it is a valid handle, so it is a valid AT-URI authority",
+		},
+		Lenient: map[string]string{
+			"did":                interopLenientAuthority,
+			"didmethodval":       interopLenientAuthority,
+			"method:did:val":     interopLenientAuthority,
+			"did:method:":        interopLenientAuthority,
+			"didmethod:val":      interopLenientAuthority,
+			"did:methodval)":     interopLenientAuthority,
+			":did:method:val":    interopLenientAuthority,
+			"did:method:val:":    interopLenientAuthority,
+			"did:method:val%":    interopLenientAuthority,
+			"DID:method:val":     interopLenientAuthority,
+			"did:METHOD:val":     interopLenientAuthority,
+			"did:m123:val":       interopLenientAuthority,
+			"did:method:val?two": interopLenientQueryFragment,
+			"did:method:val#two": interopLenientQueryFragment,
+			"did:method:" + strings.Repeat("v", 2492): interopLenientAuthority,
+		},
+	},
+
+
+
+	{
+		File: "nsid_syntax_valid.txt",
+		Valid: true,
+		Wrap: func(value string) string { return "at://did:plc:asdf123/" + value },
+		Known: map[string]string{
+			"com.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.middle.foo": "github.com/reiver/go-nsid limits the domain-authority to 253 characters",
+		},
+	},
+	{
+		File: "nsid_syntax_invalid.txt",
+		Valid: false,
+		Wrap: func(value string) string { return "at://did:plc:asdf123/" + value },
+		Lenient: map[string]string{
+			"com.atproto.feed.po#t": interopLenientQueryFragment,
+		},
+	},
+
+
+
+	{
+		File: "recordkey_syntax_valid.txt",
+		Valid: true,
+		Wrap: func(value string) string { return "at://did:plc:asdf123/com.example.record/" + value },
+	},
+	{
+		File: "recordkey_syntax_invalid.txt",
+		Valid: false,
+		Wrap: func(value string) string { return "at://did:plc:asdf123/com.example.record/" + value },
+		Lenient: map[string]string{
+			"alpha/beta": interopLenientPath,
+			".":          interopLenientRecordKey,
+			"..":         interopLenientRecordKey,
+			"@handle":    interopLenientRecordKey,
+			"any space":  interopLenientRecordKey,
+			"any+space":  interopLenientRecordKey,
+			"number[3]":  interopLenientRecordKey,
+			"number(3)":  interopLenientRecordKey,
+			"\"quote\"":  interopLenientRecordKey,
+			"dHJ1ZQ==":   interopLenientRecordKey,
+			strings.Repeat("o", 513): interopLenientRecordKey,
+		},
+	},
+}
+
+// The reasons the lenient aturi.Validate accepts an invalid test vector.
+// Each of these is a rule that only aturi.ValidateStrict checks.
+const (
+	interopLenientScheme        = "aturi.Validate matches the \"at://\" scheme case-insensitively"
+	interopLenientAuthority     = "aturi.Validate only checks that the authority is not empty and has no \"@\" in it, not that it is a handle or DID"
+	interopLenientPath          = "aturi.Validate allows a trailing slash and empty path segments, and puts everything after the collection into the rkey"
+	interopLenientRecordKey     = "aturi.Validate does not check the record-key syntax"
+	interopLenientQueryFragment = "aturi.Validate allows a query and fragment, and does not check their syntax"
+)
+
+// loadInteropVectors returns the test vectors in a file in "testdata/syntax".
+//
+// Empty lines, and lines beginning with a "#", are skipped.
+// Trailing spaces are NOT trimmed, because some test vectors depend on them.
+func loadInteropVectors(t *testing.T, file string) []string {
+	t.Helper()
+
+	bytes, err := os.ReadFile(filepath.Join("testdata", "syntax", file))
+	if nil != err {
+		t.Fatalf("Could not read test vectors file %q: (%T) %s", file, err, err)
+		return nil
+	}
+
+	var vectors []string
+	for _, line := range strings.Split(string(bytes), "\n") {
+		if "" == line || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		vectors = append(vectors, line)
+	}
+
+	return vectors
+}
+
+func TestValidateStrict_interop(t *testing.T) {
+
+	for _, test := range interopTests {
+
+		vectors := loadInteropVectors(t, test.File)
+
+		for vectorNumber, vector := range vectors {
+
+			if reason, found := test.Known[vector]; found {
+				t.Logf("KNOWN: test vector #%d of %q is skipped: %s", vectorNumber, test.File, reason)
+				continue
+			}
+
+			var uri string = test.Wrap(vector)
+
+			err := aturi.ValidateStrict(uri)
+
+			if test.Valid && nil != err {
+				t.Errorf("For test vector #%d of %q, did not expect an error but actually got one.", vectorNumber, test.File)
+				t.Logf("ERROR: (%T) %s", err, err)
+				t.Logf("VECTOR: %q", vector)
+				t.Logf("URI: %q", uri)
+				continue
+			}
+
+			if !test.Valid && nil == err {
+				t.Errorf("For test vector #%d of %q, expected an error but did not actually get one.", vectorNumber, test.File)
+				t.Logf("VECTOR: %q", vector)
+				t.Logf("URI: %q", uri)
+				continue
+			}
+		}
+	}
+}
+
+// TestValidate_interop checks the (lenient) aturi.Validate against the AT-protocol syntax interop test vectors.
+//
+// aturi.Validate accepts some inputs that the reference implementation rejects.
+// Each of those must be in the 'Lenient' allowlist (with the reason) of its file, or the test fails.
+// An allowlisted test vector that aturi.Validate does reject also fails the test, so that the allowlist does not go stale.
+func TestValidate_interop(t *testing.T) {
+
+	for _, test := range interopTests {
+
+		vectors := loadInteropVectors(t, test.File)
+
+		var found = map[string]bool{}
+
+		for vectorNumber, vector := range vectors {
+
+			if reason, known := test.Known[vector]; known {
+				t.Logf("KNOWN: test vector #%d of %q is skipped: %s", vectorNumber, test.File, reason)
+				continue
+			}
+
+			var uri string = test.Wrap(vector)
+
+			err := aturi.Validate(uri)
+
+			reason, lenient := test.Lenient[vector]
+			found[vector] = lenient
+
+			switch {
+			case lenient && nil != err:
+				t.Errorf("For test vector #%d of %q, expected aturi.Validate to (leniently) accept it, but it actually rejected it; remove it from the allowlist.", vectorNumber, test.File)
+				t.Logf("REASON: %s", reason)
+				t.Logf("ERROR: (%T) %s", err, err)
+				t.Logf("URI: %q", uri)
+			case lenient:
+				// Nothing here.
+			case test.Valid && nil != err:
+				t.Errorf("For test vector #%d of %q, did not expect an error but actually got one.", vectorNumber, test.File)
+				t.Logf("ERROR: (%T) %s", err, err)
+				t.Logf("URI: %q", uri)
+			case !test.Valid && nil == err:
+				t.Errorf("For test vector #%d of %q, expected an error but did not actually get one (and it is not in the allowlist).", vectorNumber, test.File)
+				t.Logf("URI: %q", uri)
+			}
+		}
+
+		for vector := range test.Lenient {
+			if !found[vector] {
+				t.Errorf("The allowlist of %q has a test vector that is not in the file: %q", test.File, vector)
+			}
+		}
+	}
+}
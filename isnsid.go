@@ -0,0 +1,96 @@
+package aturi
+
+// isNSID returns whether nsid.Validate (from github.com/reiver/go-nsid) would return nil for the string.
+//
+// nsid.Validate allocates (it splits the NSID into its segments, and joins the domain-authority back together),
+// and it is where nearly all the time of [Split] went for an AT-URI with a collection.
+// isNSID checks the same rules without allocating,
+// so that only an invalid collection has to go through nsid.Validate (to get its error).
+//
+// The rules are:
+// the NSID is ASCII, at most 317 characters long, and has at least 3 segments (separated by '.');
+// the domain-authority (every segment but the last) is at most 253 characters long (including the '.'s),
+// and each of its segments is 1 to 63 characters of 'a'-'z', '0'-'9', or '-' that does not begin or end with a '-',
+// and the first one does not begin with a digit;
+// the name (the last segment) is 1 to 63 characters of 'A'-'Z' or 'a'-'z'.
+func isNSID(str string) bool {
+	const maxLength int = 317
+	const maxDomainAuthorityLength int = 253
+	const maxSegmentLength int = 63
+
+	if "" == str || maxLength < len(str) {
+		return false
+	}
+
+	var lastDot int = -1
+	for index := len(str) - 1; 0 <= index; index-- {
+		if '.' == str[index] {
+			lastDot = index
+			break
+		}
+	}
+	if lastDot < 0 || maxDomainAuthorityLength < lastDot {
+		return false
+	}
+
+	// name
+	{
+		var name string = str[lastDot+1:]
+		if "" == name || maxSegmentLength < len(name) {
+			return false
+		}
+
+		for index := 0; index < len(name); index++ {
+			var b byte = name[index]
+
+			switch {
+			case 'A' <= b && b <= 'Z':
+			case 'a' <= b && b <= 'z':
+			default:
+				return false
+			}
+		}
+	}
+
+	// domain-authority
+	var numSegments int
+	{
+		var begin int = 0
+
+		for index := 0; index <= lastDot; index++ {
+			if index < lastDot && '.' != str[index] {
+				continue
+			}
+
+			var segment string = str[begin:index]
+			begin = index + 1
+
+			if "" == segment || maxSegmentLength < len(segment) {
+				return false
+			}
+			if '-' == segment[0] || '-' == segment[len(segment)-1] {
+				return false
+			}
+			if 0 == numSegments && '0' <= segment[0] && segment[0] <= '9' {
+				return false
+			}
+
+			for i := 0; i < len(segment); i++ {
+				var b byte = segment[i]
+
+				switch {
+				case 'a' <= b && b <= 'z':
+				case '0' <= b && b <= '9':
+				case '-' == b:
+				default:
+					return false
+				}
+			}
+
+			numSegments++
+		}
+	}
+
+	// The domain-authority must have at least 2 segments (so the NSID has at least 3).
+	return 2 <= numSegments
+}
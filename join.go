@@ -0,0 +1,44 @@
+package aturi
+
+import (
+	"strings"
+)
+
+// Join returns the AT-URI made up of the 'authority', 'collection', 'rkey', 'query', and 'fragment'.
+//
+// Join is the inverse of [Split].
+// Empty components are left out.
+//
+// Join does NOT validate the AT-URI.
+// To validate, call [Validate].
+//
+// For example:
+//
+//	var uri string = aturi.Join("did:plc:scewmn2pl3oz36mxme2b6czz", "com.example.foorBar", "3jui7kd54zh2y", "", "")
+//	
+//	// uri == "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y"
+func Join(authority string, collection string, rkey string, query string, fragment string) string {
+	var buffer strings.Builder
+
+	buffer.WriteString("at://")
+	buffer.WriteString(authority)
+
+	if "" != collection || "" != rkey {
+		buffer.WriteString("/")
+		buffer.WriteString(collection)
+	}
+	if "" != rkey {
+		buffer.WriteString("/")
+		buffer.WriteString(rkey)
+	}
+	if "" != query {
+		buffer.WriteString("?")
+		buffer.WriteString(query)
+	}
+	if "" != fragment {
+		buffer.WriteString("#")
+		buffer.WriteString(fragment)
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,67 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestJoin(t *testing.T) {
+
+	tests := []struct{
+		Authority string
+		Collection string
+		RKey string
+		Query string
+		Fragment string
+		Expected string
+	}{
+		{
+			Authority: "example.com",
+			Expected: "at://example.com",
+		},
+		{
+			Authority:  "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Collection: "com.example.foorBar",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar",
+		},
+		{
+			Authority:  "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Collection: "com.example.foorBar",
+			RKey:       "3jui7kd54zh2y",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y",
+		},
+		{
+			Authority:  "did:plc:scewmn2pl3oz36mxme2b6czz",
+			RKey:       "3jui7kd54zh2y",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz//3jui7kd54zh2y",
+		},
+		{
+			Authority:  "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Collection: "com.example.foorBar",
+			RKey:       "3jui7kd54zh2y",
+			Query:      "once=1&twice=2&thrice=3&fource=4",
+			Fragment:   "path(/apple/banana/cherry)",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
+		},
+		{
+			Authority:  "example.com",
+			Fragment:   "path(/apple/banana/cherry)",
+			Expected: "at://example.com#path(/apple/banana/cherry)",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual := aturi.Join(test.Authority, test.Collection, test.RKey, test.Query, test.Fragment)
+
+		expected := test.Expected
+
+		if expected != actual {
+			t.Errorf("For test #%d, the actual joined AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
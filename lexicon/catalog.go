@@ -0,0 +1,166 @@
+package lexicon
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/reiver/go-erorr"
+	"github.com/reiver/go-nsid"
+)
+
+// Catalog is a set of Lexicon schemas.
+//
+// Add must not be called at the same time as anything else.
+// Once all the schemas are added, the Validate methods are safe to use from many goroutines at the same time.
+type Catalog struct {
+	documents map[string]*document
+}
+
+// Load loads every Lexicon JSON file (every file whose name ends in ".json") in the directory, and in the directories under it.
+func Load(dir string) (*Catalog, error) {
+	return LoadFS(os.DirFS(dir))
+}
+
+// LoadFS is like [Load] but loads the Lexicon JSON files from a file-system (such as an embed.FS).
+func LoadFS(fsys fs.FS) (*Catalog, error) {
+	if nil == fsys {
+		return nil, errNilFS
+	}
+
+	var catalog Catalog
+
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if nil != err {
+			return err
+		}
+		if entry.IsDir() || ".json" != path.Ext(name) {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if nil != err {
+			return err
+		}
+
+		if err := catalog.Add(data); nil != err {
+			return erorr.Errorf("lexicon: problem loading %q: %w", name, err)
+		}
+
+		return nil
+	})
+	if nil != err {
+		return nil, err
+	}
+
+	return &catalog, nil
+}
+
+// Add adds the Lexicon schema in a Lexicon JSON file to the catalog.
+func (receiver *Catalog) Add(data []byte) error {
+	if nil == receiver {
+		return errNilCatalog
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); nil != err {
+		return erorr.Errorf("lexicon: problem unmarshaling Lexicon JSON: %w", err)
+	}
+
+	if 1 != doc.Lexicon {
+		return erorr.Errorf("lexicon: Lexicon %q has version %d but only version 1 is supported", doc.ID, doc.Lexicon)
+	}
+	if err := nsid.Validate(doc.ID); nil != err {
+		return erorr.Errorf("lexicon: Lexicon id %q is not a valid NSID: %w", doc.ID, err)
+	}
+
+	// The definitions are checked in sorted order, so that which error is returned does not depend on map order.
+	var names []string
+	for name := range doc.Defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var def *definition = doc.Defs[name]
+		if nil == def || "record" != def.Type {
+			continue
+		}
+		if "main" != name {
+			return erorr.Errorf("lexicon: Lexicon %q has a record definition %q that is not \"main\"", doc.ID, name)
+		}
+		if err := validateKeyType(def.Key); nil != err {
+			return erorr.Errorf("lexicon: Lexicon %q has a bad record-key type: %w", doc.ID, err)
+		}
+	}
+
+	if nil == receiver.documents {
+		receiver.documents = map[string]*document{}
+	}
+	if _, found := receiver.documents[doc.ID]; found {
+		return erorr.Errorf("lexicon: Lexicon %q was already added", doc.ID)
+	}
+
+	receiver.documents[doc.ID] = &doc
+	return nil
+}
+
+// Len returns the number of Lexicon schemas in the catalog.
+func (receiver *Catalog) Len() int {
+	if nil == receiver {
+		return 0
+	}
+	return len(receiver.documents)
+}
+
+// record returns the "main" record definition for the collection, if there is one.
+func (receiver *Catalog) record(collection string) (*definition, bool) {
+	def, found := receiver.lookup(collection, "")
+	if !found || "record" != def.Type || nil == def.Record {
+		return nil, false
+	}
+	return def, true
+}
+
+// lookup returns the definition that a reference (such as "app.bsky.feed.post#replyRef", "#replyRef", or "app.bsky.feed.post") refers to.
+// A reference that begins with a '#' is relative to the Lexicon with the id 'base'.
+func (receiver *Catalog) lookup(ref string, base string) (*definition, bool) {
+	if nil == receiver {
+		return nil, false
+	}
+
+	var id string = ref
+	var name string = "main"
+
+	if index := strings.IndexByte(ref, '#'); 0 <= index {
+		id = ref[:index]
+		name = ref[index+1:]
+	}
+	if "" == id {
+		id = base
+	}
+
+	doc, found := receiver.documents[id]
+	if !found {
+		return nil, false
+	}
+
+	def, found := doc.Defs[name]
+	if !found || nil == def {
+		return nil, false
+	}
+
+	return def, true
+}
+
+// resolve returns the fully-qualified form of a reference (such as "app.bsky.feed.post#replyRef"), without any "#main".
+// A reference that begins with a '#' is relative to the Lexicon with the id 'base'.
+func resolve(ref string, base string) string {
+	if strings.HasPrefix(ref, "#") {
+		ref = base + ref
+	}
+	return strings.TrimSuffix(ref, "#main")
+}
@@ -0,0 +1,80 @@
+package lexicon_test
+
+import (
+	"testing"
+
+	"os"
+
+	"github.com/reiver/go-aturi/lexicon"
+)
+
+func loadTestCatalog(t *testing.T) *lexicon.Catalog {
+	t.Helper()
+
+	catalog, err := lexicon.Load("../lexicons")
+	if nil != err {
+		t.Fatalf("Did not expect an error when loading the Lexicons but actually got one: (%T) %s", err, err)
+	}
+
+	// "com.example.preference" is not a real Lexicon. It is only here for its "nsid" record-key type, which no well-known Lexicon uses.
+	{
+		data, err := os.ReadFile("testdata/com.example.preference.json")
+		if nil != err {
+			t.Fatalf("Did not expect an error when reading the test Lexicon but actually got one: (%T) %s", err, err)
+		}
+
+		if err := catalog.Add(data); nil != err {
+			t.Fatalf("Did not expect an error when adding the test Lexicon but actually got one: (%T) %s", err, err)
+		}
+	}
+
+	return catalog
+}
+
+func TestLoad(t *testing.T) {
+
+	catalog := loadTestCatalog(t)
+
+	if expected, actual := 16, catalog.Len(); expected != actual {
+		t.Errorf("The actual number of Lexicons is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+}
+
+func TestCatalog_Add_fail(t *testing.T) {
+
+	tests := []string{
+		``,
+		`{"lexicon": 2, "id": "com.example.thing", "defs": {}}`,
+		`{"lexicon": 1, "id": "thing", "defs": {}}`,
+		`{"lexicon": 1, "id": "com.example.thing", "defs": {"main": {"type": "record", "key": "uuid", "record": {"type": "object"}}}}`,
+		`{"lexicon": 1, "id": "com.example.thing", "defs": {"main": {"type": "record", "key": "literal:", "record": {"type": "object"}}}}`,
+		`{"lexicon": 1, "id": "com.example.thing", "defs": {"other": {"type": "record", "key": "tid", "record": {"type": "object"}}}}`,
+	}
+
+	for testNumber, test := range tests {
+
+		var catalog lexicon.Catalog
+
+		if err := catalog.Add([]byte(test)); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("JSON: %s", test)
+			continue
+		}
+	}
+}
+
+func TestCatalog_Add_duplicate(t *testing.T) {
+
+	const data string = `{"lexicon": 1, "id": "com.example.thing", "defs": {"main": {"type": "record", "key": "tid", "record": {"type": "object"}}}}`
+
+	var catalog lexicon.Catalog
+
+	if err := catalog.Add([]byte(data)); nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+	if err := catalog.Add([]byte(data)); nil == err {
+		t.Errorf("Expected an error when adding the same Lexicon twice but did not actually get one.")
+	}
+}
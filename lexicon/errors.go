@@ -0,0 +1,11 @@
+package lexicon
+
+import (
+	"github.com/reiver/go-erorr"
+)
+
+const (
+	errEmptyCollection = erorr.Error("lexicon: empty collection")
+	errNilCatalog      = erorr.Error("lexicon: nil catalog")
+	errNilFS           = erorr.Error("lexicon: nil file-system")
+)
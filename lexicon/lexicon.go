@@ -0,0 +1,16 @@
+// Package lexicon validates AT-URIs, and the 'at-uri' fields of records, against Lexicon schemas.
+//
+// The schemas are loaded from Lexicon JSON files (such as those in the "lexicons/" directory of the atproto repository),
+// so no network access is needed.
+//
+// For example:
+//
+//	catalog, err := lexicon.Load("./lexicons")
+//	if nil != err {
+//		return err
+//	}
+//	
+//	err = catalog.ValidateURI("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y")
+//	
+//	err = catalog.ValidateRecord("app.bsky.feed.like", recordJSON)
+package lexicon
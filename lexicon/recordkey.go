@@ -0,0 +1,81 @@
+package lexicon
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+	"github.com/reiver/go-nsid"
+)
+
+// The record-key types a Lexicon record definition may declare (in its "key").
+const (
+	keyTypeTID     = "tid"      // a TID (timestamp identifier)
+	keyTypeNSID    = "nsid"     // an NSID
+	keyTypeAny     = "any"      // any valid record-key
+	keyTypeLiteral = "literal:" // exactly what comes after the "literal:", such as "literal:self"
+)
+
+// validateKeyType returns an error if the record-key type is not one that Lexicon allows.
+func validateKeyType(keyType string) error {
+	switch keyType {
+	case keyTypeTID, keyTypeNSID, keyTypeAny:
+		return nil
+	}
+
+	if strings.HasPrefix(keyType, keyTypeLiteral) && len(keyTypeLiteral) < len(keyType) {
+		return nil
+	}
+
+	return erorr.Errorf("lexicon: unknown record-key type %q", keyType)
+}
+
+// validateKey returns an error if the rkey is not of the record-key type.
+//
+// The rkey must already be a valid record-key.
+func validateKey(keyType string, rkey string) error {
+	switch {
+	case keyTypeTID == keyType:
+		return validateTID(rkey)
+	case keyTypeNSID == keyType:
+		if err := nsid.Validate(rkey); nil != err {
+			return erorr.Errorf("lexicon: rkey %q is not an NSID: %w", rkey, err)
+		}
+		return nil
+	case keyTypeAny == keyType:
+		return nil
+	case strings.HasPrefix(keyType, keyTypeLiteral):
+		var literal string = keyType[len(keyTypeLiteral):]
+		if literal != rkey {
+			return erorr.Errorf("lexicon: rkey %q is not %q", rkey, literal)
+		}
+		return nil
+	default:
+		return erorr.Errorf("lexicon: unknown record-key type %q", keyType)
+	}
+}
+
+// validateTID returns an error if the string is not a TID (timestamp identifier).
+//
+// A TID is 13 characters of base32-sortable ('2'-'7', 'a'-'z'),
+// and its first character is one of '2'-'7' or 'a'-'j' (so that the top bit is 0).
+func validateTID(tid string) error {
+	const length int = 13
+
+	if length != len(tid) {
+		return erorr.Errorf("lexicon: rkey %q is not a TID because it is %d characters long rather than %d", tid, len(tid), length)
+	}
+
+	for index := 0; index < len(tid); index++ {
+		var b byte = tid[index]
+
+		switch {
+		case '2' <= b && b <= '7':
+		case 'a' <= b && b <= 'j':
+		case 'k' <= b && b <= 'z' && 0 < index:
+		default:
+			return erorr.Errorf("lexicon: rkey %q is not a TID because character №%d (%q) is not allowed there", tid, index, b)
+		}
+	}
+
+	return nil
+}
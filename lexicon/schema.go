@@ -0,0 +1,34 @@
+package lexicon
+
+// document is a Lexicon JSON file.
+type document struct {
+	Lexicon int                    `json:"lexicon"`
+	ID      string                 `json:"id"`
+	Defs    map[string]*definition `json:"defs"`
+}
+
+// definition is a definition in a Lexicon document (or a schema nested in one).
+//
+// Only the parts of the Lexicon language that are needed to find 'at-uri' fields, and record-key types, are here.
+type definition struct {
+	Type string `json:"type"`
+
+	// "string"
+	Format string `json:"format,omitempty"`
+
+	// "record"
+	Key    string      `json:"key,omitempty"`
+	Record *definition `json:"record,omitempty"`
+
+	// "object"
+	Properties map[string]*definition `json:"properties,omitempty"`
+
+	// "array"
+	Items *definition `json:"items,omitempty"`
+
+	// "ref"
+	Ref string `json:"ref,omitempty"`
+
+	// "union"
+	Refs []string `json:"refs,omitempty"`
+}
@@ -0,0 +1,136 @@
+package lexicon
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+	"github.com/reiver/go-erorr"
+)
+
+// ValidateRecord returns an error if any 'at-uri' field (a "string" with "format": "at-uri") of the record is not a valid AT-URI.
+//
+// 'record' is the JSON of the record, and 'collection' is its record type (such as "app.bsky.feed.like").
+// The record is walked along with its schema — through objects, arrays, refs, and unions (using the "$type" of the union value) —
+// and each 'at-uri' field must pass [aturi.ValidateStrict].
+//
+// Every bad field is reported (joined together with errors.Join), with its location in the record (such as "reply.parent.uri").
+// The properties of each object are walked in sorted order, so the errors are always in the same order.
+//
+// ValidateRecord only checks 'at-uri' fields.
+// It does not check that required fields are there, or that the other fields have the right types.
+func (receiver *Catalog) ValidateRecord(collection string, record []byte) error {
+	if nil == receiver {
+		return errNilCatalog
+	}
+	if "" == collection {
+		return errEmptyCollection
+	}
+
+	def, found := receiver.record(collection)
+	if !found {
+		return erorr.Errorf("lexicon: collection %q is not a record type in the catalog", collection)
+	}
+
+	var value any
+	if err := json.Unmarshal(record, &value); nil != err {
+		return erorr.Errorf("lexicon: problem unmarshaling %q record from JSON: %w", collection, err)
+	}
+
+	var errs []error
+	receiver.walk(def.Record, collection, value, "", func(field string, err error) {
+		errs = append(errs, erorr.Errorf("lexicon: %q record field %q: %w", collection, field, err))
+	})
+
+	return errors.Join(errs...)
+}
+
+// walk walks the value along with its schema, and calls fn for each 'at-uri' field that is not valid.
+// 'base' is the id of the Lexicon that the schema is in (for refs that begin with a '#').
+func (receiver *Catalog) walk(def *definition, base string, value any, field string, fn func(string, error)) {
+	if nil == def || nil == value {
+		return
+	}
+
+	switch def.Type {
+	case "string":
+		str, ok := value.(string)
+		if !ok || "at-uri" != def.Format {
+			return
+		}
+		if err := aturi.ValidateStrict(str); nil != err {
+			fn(field, err)
+		}
+
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		// The properties are walked in sorted order, so that the errors are always in the same order.
+		var names []string
+		for name := range def.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			receiver.walk(def.Properties[name], base, object[name], join(field, name), fn)
+		}
+
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return
+		}
+		for index, item := range array {
+			receiver.walk(def.Items, base, item, field+"["+strconv.Itoa(index)+"]", fn)
+		}
+
+	case "ref":
+		receiver.walkRef(def.Ref, base, value, field, fn)
+
+	case "union":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		typ, _ := object["$type"].(string)
+		for _, ref := range def.Refs {
+			if resolve(ref, base) == resolve(typ, base) {
+				receiver.walkRef(ref, base, value, field, fn)
+				return
+			}
+		}
+		// An open union may have a $type that is not one of its refs. There is no schema to check it against.
+
+	case "record":
+		receiver.walk(def.Record, base, value, field, fn)
+	}
+}
+
+func (receiver *Catalog) walkRef(ref string, base string, value any, field string, fn func(string, error)) {
+	def, found := receiver.lookup(ref, base)
+	if !found {
+		fn(field, erorr.Errorf("lexicon: unknown ref %q", resolve(ref, base)))
+		return
+	}
+
+	var id string = resolve(ref, base)
+	if index := strings.IndexByte(id, '#'); 0 <= index {
+		id = id[:index]
+	}
+
+	receiver.walk(def, id, value, field, fn)
+}
+
+// join returns the location of a property of the field (such as "reply.parent").
+func join(field string, name string) string {
+	if "" == field {
+		return name
+	}
+	return field + "." + name
+}
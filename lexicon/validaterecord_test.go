@@ -0,0 +1,179 @@
+package lexicon_test
+
+import (
+	"testing"
+
+	"strings"
+)
+
+func TestCatalog_ValidateRecord(t *testing.T) {
+
+	catalog := loadTestCatalog(t)
+
+	tests := []struct{
+		Collection string
+		Record string
+	}{
+		{
+			Collection: "app.bsky.feed.like",
+			Record: `{
+				"$type": "app.bsky.feed.like",
+				"subject": {
+					"uri": "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+					"cid": "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a"
+				},
+				"createdAt": "2024-09-01T12:00:00.000Z"
+			}`,
+		},
+		{
+			Collection: "app.bsky.feed.post",
+			Record: `{
+				"$type": "app.bsky.feed.post",
+				"text": "at://this/is/not/an/at-uri/field",
+				"reply": {
+					"root":   {"uri": "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y", "cid": "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a"},
+					"parent": {"uri": "at://reiver.bsky.social/app.bsky.feed.post/3l3qo2vuowo2b",             "cid": "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a"}
+				},
+				"embed": {
+					"$type": "app.bsky.embed.record",
+					"record": {"uri": "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3l3qo2vuowo2b", "cid": "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a"}
+				},
+				"createdAt": "2024-09-01T12:00:00.000Z"
+			}`,
+		},
+		{
+			Collection: "app.bsky.feed.post",
+			Record: `{
+				"text": "an open union",
+				"embed": {"$type": "app.bsky.embed.images", "images": []},
+				"createdAt": "2024-09-01T12:00:00.000Z"
+			}`,
+		},
+		{
+			Collection: "app.bsky.feed.threadgate",
+			Record: `{
+				"post": "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+				"allow": [
+					{"$type": "app.bsky.feed.threadgate#mentionRule"},
+					{"$type": "app.bsky.feed.threadgate#listRule", "list": "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o"}
+				],
+				"hiddenReplies": ["at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3l3qo2vuowo2c"],
+				"createdAt": "2024-09-01T12:00:00.000Z"
+			}`,
+		},
+		{
+			Collection: "app.bsky.actor.profile",
+			Record: `{"displayName": "Charles"}`,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if err := catalog.ValidateRecord(test.Collection, []byte(test.Record)); nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("COLLECTION: %q", test.Collection)
+			continue
+		}
+	}
+}
+
+func TestCatalog_ValidateRecord_fail(t *testing.T) {
+
+	catalog := loadTestCatalog(t)
+
+	tests := []struct{
+		Collection string
+		Record string
+		ExpectedFields []string
+	}{
+		{
+			Collection: "app.bsky.feed.like",
+			Record: `{"subject": {"uri": "AT://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y", "cid": "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a"}}`,
+			ExpectedFields: []string{`"subject.uri"`},
+		},
+		{
+			Collection: "app.bsky.feed.post",
+			Record: `{
+				"reply": {
+					"root":   {"uri": "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?x=1"},
+					"parent": {"uri": "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/"}
+				},
+				"embed": {"$type": "app.bsky.embed.record#main", "record": {"uri": "https://bsky.app/"}}
+			}`,
+			ExpectedFields: []string{`"embed.record.uri"`, `"reply.parent.uri"`, `"reply.root.uri"`},
+		},
+		{
+			Collection: "app.bsky.feed.threadgate",
+			Record: `{
+				"post": "at://example/app.bsky.feed.post/3jui7kd54zh2y",
+				"allow": [
+					{"$type": "app.bsky.feed.threadgate#followingRule"},
+					{"$type": "app.bsky.feed.threadgate#listRule", "list": "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o#frag"}
+				],
+				"hiddenReplies": ["at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3l3qo2vuowo2c", ""]
+			}`,
+			ExpectedFields: []string{`"allow[1].list"`, `"hiddenReplies[1]"`, `"post"`},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		err := catalog.ValidateRecord(test.Collection, []byte(test.Record))
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("COLLECTION: %q", test.Collection)
+			continue
+		}
+
+		// The errors are in sorted order of the fields, one per line.
+		var lines []string = strings.Split(err.Error(), "\n")
+
+		if expected, actual := len(test.ExpectedFields), len(lines); expected != actual {
+			t.Errorf("For test #%d, the actual number of errors is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			t.Logf("ERROR: %s", err)
+			continue
+		}
+
+		for index, field := range test.ExpectedFields {
+			if !strings.Contains(lines[index], "field "+field+":") {
+				t.Errorf("For test #%d, expected error №%d to be about field %s but it actually is not.", testNumber, index, field)
+				t.Logf("ERROR: %s", lines[index])
+			}
+		}
+	}
+}
+
+func TestCatalog_ValidateRecord_badInput(t *testing.T) {
+
+	catalog := loadTestCatalog(t)
+
+	tests := []struct{
+		Collection string
+		Record string
+	}{
+		{
+			Collection: "",
+			Record:     `{}`,
+		},
+		{
+			Collection: "com.example.unknown",
+			Record:     `{}`,
+		},
+		{
+			Collection: "app.bsky.feed.like",
+			Record:     `{`,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if err := catalog.ValidateRecord(test.Collection, []byte(test.Record)); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("COLLECTION: %q", test.Collection)
+			continue
+		}
+	}
+}
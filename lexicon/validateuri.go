@@ -0,0 +1,47 @@
+package lexicon
+
+import (
+	"github.com/reiver/go-aturi"
+	"github.com/reiver/go-erorr"
+)
+
+// ValidateURI returns an error if the AT-URI is not valid for the Lexicon schema of its collection.
+//
+// The AT-URI must pass [aturi.ValidateStrict].
+// If it has an rkey, then its collection must be a record type in the catalog,
+// and the rkey must be of the record-key type that the record type declares ("tid", "nsid", "literal:…", or "any").
+//
+// An rkey of the wrong type is returned as an [*aturi.Error] of kind [aturi.ErrorKindRecordKey].
+func (receiver *Catalog) ValidateURI(uri string) error {
+	if nil == receiver {
+		return errNilCatalog
+	}
+
+	if err := aturi.ValidateStrict(uri); nil != err {
+		return err
+	}
+
+	_, collection, rkey, _, _, _ := aturi.Split(uri)
+	if "" == rkey {
+		return nil
+	}
+
+	def, found := receiver.record(collection)
+	if !found {
+		return &aturi.Error{
+			Kind: aturi.ErrorKindCollection,
+			URI:  uri,
+			Err:  erorr.Errorf("lexicon: URI %q has a collection %q that is not a record type in the catalog", uri, collection),
+		}
+	}
+
+	if err := validateKey(def.Key, rkey); nil != err {
+		return &aturi.Error{
+			Kind: aturi.ErrorKindRecordKey,
+			URI:  uri,
+			Err:  erorr.Errorf("lexicon: URI %q has an rkey %q that is not of the %q record-key type of %q: %w", uri, rkey, def.Key, collection, err),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package lexicon_test
+
+import (
+	"testing"
+
+	"errors"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestCatalog_ValidateURI(t *testing.T) {
+
+	catalog := loadTestCatalog(t)
+
+	tests := []string{
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		"at://reiver.bsky.social/app.bsky.feed.like/3l3qo2vusxu2b",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats-hot",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/3jui7kd54zh2y",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.preference/app.bsky.feed.post",
+	}
+
+	for testNumber, uri := range tests {
+
+		if err := catalog.ValidateURI(uri); nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", uri)
+			continue
+		}
+	}
+}
+
+func TestCatalog_ValidateURI_fail(t *testing.T) {
+
+	catalog := loadTestCatalog(t)
+
+	tests := []struct{
+		URI string
+		ExpectedKind aturi.ErrorKind
+	}{
+		{
+			URI:          "AT://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/self",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/kjui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.preference/whats-hot",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.unknown/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindCollection,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.atproto.repo.strongRef/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindCollection,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		err := catalog.ValidateURI(test.URI)
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		var aturiError *aturi.Error
+		if !errors.As(err, &aturiError) {
+			t.Errorf("For test #%d, expected the error to be an %T but it actually is not.", testNumber, aturiError)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := test.ExpectedKind, aturiError.Kind; expected != actual {
+			t.Errorf("For test #%d, the actual error kind is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("ERROR: %s", err)
+			continue
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package aturi
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/reiver/go-nsid"
+)
+
+// Severity says how bad a problem found by [Lint] is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // the AT-URI is invalid where AT-URIs are used (such as in records), or is not an AT-URI at all
+	SeverityWarning Severity = "warning" // the AT-URI is probably not what was meant, or is not in its normal form
+)
+
+// Diagnostic is a problem with an AT-URI, found by [Lint].
+type Diagnostic struct {
+	Begin int // byte offset of the first byte of the problem
+	End   int // byte offset just past the last byte of the problem (equal to Begin if something is missing)
+
+	Severity Severity
+	Kind     ErrorKind
+	Message  string
+
+	// Suggestion is the AT-URI with (only) this problem fixed, or "" if there is no suggested fix.
+	Suggestion string
+}
+
+// Lint returns every problem it can find with the AT-URI, each with its byte span, severity, and (if there is one) a suggested fix.
+//
+// Lint is for helping people fix AT-URIs (such as ones handed to us by someone else) and so it looks for common mistakes, such as:
+// a bsky.app web link rather than an AT-URI,
+// "at:/" with a single slash,
+// an "@" at the beginning of a handle,
+// an upper-case DID method,
+// a trailing dot at the end of a handle,
+// an rkey with no collection before it,
+// a collection that is almost (but not quite) a well-known NSID,
+// and a collection that is not a well-known collection but is in the namespace of well-known collections (such as "app.bsky.feed.thing").
+//
+// For example:
+//
+//	var uri string = "at://@reiver.bsky.social/app.bsky.feed.psot/3jui7kd54zh2y"
+//	
+//	diagnostics := aturi.Lint(uri)
+//	
+//	fmt.Print(aturi.FormatDiagnostics(uri, diagnostics))
+//
+// Lint returns nil if it does not find any problems.
+// (A nil return does not mean the AT-URI passes [ValidateStrict].)
+func Lint(uri string) []Diagnostic {
+	var linter = linter{uri: uri}
+	linter.lint()
+	return linter.diagnostics
+}
+
+type linter struct {
+	uri         string
+	diagnostics []Diagnostic
+}
+
+func (receiver *linter) add(severity Severity, kind ErrorKind, begin int, end int, message string) {
+	receiver.diagnostics = append(receiver.diagnostics, Diagnostic{
+		Begin:    begin,
+		End:      end,
+		Severity: severity,
+		Kind:     kind,
+		Message:  message,
+	})
+}
+
+// addFix is like add, but with a suggested fix that replaces the span with 'replacement'.
+func (receiver *linter) addFix(severity Severity, kind ErrorKind, begin int, end int, message string, replacement string) {
+	receiver.diagnostics = append(receiver.diagnostics, Diagnostic{
+		Begin:      begin,
+		End:        end,
+		Severity:   severity,
+		Kind:       kind,
+		Message:    message,
+		Suggestion: receiver.uri[:begin] + replacement + receiver.uri[end:],
+	})
+}
+
+func (receiver *linter) hasErrors() bool {
+	for _, diagnostic := range receiver.diagnostics {
+		if SeverityError == diagnostic.Severity {
+			return true
+		}
+	}
+	return false
+}
+
+func (receiver *linter) lint() {
+	var uri string = receiver.uri
+
+	if "" == uri {
+		receiver.add(SeverityError, ErrorKindEmpty, 0, 0, "the AT-URI is empty")
+		return
+	}
+
+	var offset int = receiver.lintScheme()
+	if offset < 0 {
+		return
+	}
+
+	authority, collection, rkey, _, _ := splitComponents(uri[offset:])
+
+	var authorityBegin  int = offset
+	var collectionBegin int = authorityBegin + len(authority) + 1
+	var rkeyBegin       int = collectionBegin + len(collection) + 1
+
+	receiver.lintAuthority(authority, authorityBegin)
+	receiver.lintCollection(collection, collectionBegin, rkey)
+	receiver.lintRKey(rkey, rkeyBegin, collection, collectionBegin)
+
+	if !receiver.hasErrors() {
+		if err := Validate(uri); nil != err {
+			var kind ErrorKind
+			var aturiError *Error
+			if errors.As(err, &aturiError) {
+				kind = aturiError.Kind
+			}
+			receiver.add(SeverityError, kind, 0, len(uri), message(err))
+		}
+	}
+}
+
+// lintScheme lints the scheme, and returns the byte offset of the authority,
+// or -1 if the rest of the AT-URI should not be linted (because it is not an AT-URI).
+func (receiver *linter) lintScheme() int {
+	var uri string = receiver.uri
+
+	const prefix string = "at://"
+
+	switch {
+	case strings.HasPrefix(uri, prefix):
+		return len(prefix)
+	case hasPrefixFold(uri, prefix):
+		receiver.addFix(SeverityWarning, ErrorKindScheme, 0, len(prefix), "the scheme should be a lower-case \"at://\"", prefix)
+		return len(prefix)
+	case hasPrefixFold(uri, "at:/"):
+		receiver.addFix(SeverityError, ErrorKindScheme, 0, len("at:/"), "\"at:/\" has only 1 slash but should have 2", prefix)
+		return len("at:/")
+	case hasPrefixFold(uri, "at:"):
+		receiver.addFix(SeverityError, ErrorKindScheme, 0, len("at:"), "\"at:\" should be followed by \"//\"", prefix)
+		return len("at:")
+	case hasPrefixFold(uri, "https://"), hasPrefixFold(uri, "http://"):
+		converted, err := FromBskyAppURL(uri)
+		if nil != err {
+			receiver.add(SeverityError, ErrorKindScheme, 0, strings.Index(uri, "://")+len("://"), "this is a web link, not an AT-URI")
+			return -1
+		}
+		receiver.addFix(SeverityError, ErrorKindScheme, 0, len(uri), "this is a bsky.app web link, not an AT-URI", converted)
+		return -1
+	default:
+		var end int = len(uri)
+		if index := strings.Index(uri, "://"); 0 <= index {
+			end = index + len("://")
+		}
+		receiver.add(SeverityError, ErrorKindScheme, 0, end, "an AT-URI should begin with \"at://\"")
+		return -1
+	}
+}
+
+func (receiver *linter) lintAuthority(authority string, begin int) {
+	if "" == authority {
+		receiver.add(SeverityError, ErrorKindAuthority, begin, begin, "the authority (a handle or DID) is missing")
+		return
+	}
+
+	if '@' == authority[0] {
+		receiver.addFix(SeverityError, ErrorKindAuthority, begin, begin+1, "the authority should not begin with an \"@\"", "")
+		authority = authority[1:]
+		begin++
+	}
+
+	if index := strings.IndexByte(authority, '@'); 0 <= index {
+		receiver.add(SeverityError, ErrorKindAuthority, begin+index, begin+index+1, "the authority may not have an \"@\" in it")
+		return
+	}
+
+	if hasPrefixFold(authority, "did:") {
+		receiver.lintDID(authority, begin)
+		return
+	}
+
+	receiver.lintHandle(authority, begin)
+}
+
+func (receiver *linter) lintDID(did string, begin int) {
+	var fixed string = did
+
+	if !strings.HasPrefix(did, "did:") {
+		receiver.addFix(SeverityError, ErrorKindAuthority, begin, begin+len("did"), "\"did\" should be lower-case", "did")
+		fixed = "did" + fixed[len("did"):]
+	}
+
+	var method string = did[len("did:"):]
+	if index := strings.IndexByte(method, ':'); 0 <= index {
+		method = method[:index]
+	}
+	if lower := strings.ToLower(method); lower != method {
+		var methodBegin int = begin + len("did:")
+		receiver.addFix(SeverityError, ErrorKindAuthority, methodBegin, methodBegin+len(method), "the DID method should be lower-case", lower)
+		fixed = fixed[:len("did:")] + lower + fixed[len("did:")+len(method):]
+	}
+
+	if err := validateDID(fixed); nil != err {
+		receiver.add(SeverityError, ErrorKindAuthority, begin, begin+len(did), message(err))
+	}
+}
+
+func (receiver *linter) lintHandle(handle string, begin int) {
+	var fixed string = handle
+
+	if trimmed := strings.TrimRight(handle, "."); trimmed != handle && "" != trimmed {
+		var dotBegin int = begin + len(trimmed)
+		receiver.addFix(SeverityError, ErrorKindAuthority, dotBegin, begin+len(handle), "a handle should not end with a \".\"", "")
+		fixed = trimmed
+	}
+
+	if err := validateHandle(fixed); nil != err {
+		receiver.add(SeverityError, ErrorKindAuthority, begin, begin+len(fixed), message(err))
+	}
+}
+
+func (receiver *linter) lintCollection(collection string, begin int, rkey string) {
+	if "" == collection {
+		if "" != rkey {
+			receiver.add(SeverityError, ErrorKindPath, begin, begin, "the collection is missing (an rkey must come after a collection)")
+		}
+		return
+	}
+
+	if err := nsid.Validate(collection); nil != err {
+		if suggestion, found := nearCollection(collection); found {
+			receiver.addFix(SeverityError, ErrorKindCollection, begin, begin+len(collection), "the collection is not a valid NSID; did you mean "+quote(suggestion)+"?", suggestion)
+			return
+		}
+
+		if "" == rkey && !strings.Contains(collection, ".") && nil == validateRecordKey(collection) {
+			receiver.add(SeverityError, ErrorKindPath, begin-1, begin-1, "the collection is missing; "+quote(collection)+" looks like an rkey, but an rkey must come after a collection")
+			return
+		}
+
+		receiver.add(SeverityError, ErrorKindCollection, begin, begin+len(collection), "the collection is not a valid NSID: "+message(err))
+		return
+	}
+
+	if suggestion, found := nearCollection(collection); found {
+		receiver.addFix(SeverityWarning, ErrorKindCollection, begin, begin+len(collection), "the collection is not a well-known NSID; did you mean "+quote(suggestion)+"?", suggestion)
+		return
+	}
+
+	if namespace, found := unknownCollectionNamespace(collection); found {
+		receiver.add(SeverityWarning, ErrorKindCollection, begin, begin+len(collection), "the collection is not a well-known collection, even though "+quote(namespace)+" is the namespace of well-known collections")
+	}
+}
+
+func (receiver *linter) lintRKey(rkey string, begin int, collection string, collectionBegin int) {
+	if "" == rkey {
+		// a trailing slash after the collection (or after the authority)
+		var end int = collectionBegin + len(collection)
+		if "" == collection {
+			end = collectionBegin - 1
+		}
+		if end < len(receiver.uri) && '/' == receiver.uri[end] {
+			receiver.addFix(SeverityError, ErrorKindPath, end, end+1, "an AT-URI should not end with a \"/\"", "")
+		}
+		return
+	}
+
+	if index := strings.IndexByte(rkey, '/'); 0 <= index {
+		if len(rkey)-1 == index {
+			receiver.addFix(SeverityError, ErrorKindPath, begin+index, begin+index+1, "an AT-URI should not end with a \"/\"", "")
+			rkey = rkey[:index]
+		} else {
+			receiver.add(SeverityError, ErrorKindPath, begin+index, begin+len(rkey), "an AT-URI may not have more path segments than a collection and an rkey")
+			return
+		}
+	}
+
+	if err := validateRecordKey(rkey); nil != err {
+		receiver.add(SeverityError, ErrorKindRecordKey, begin, begin+len(rkey), message(err))
+	}
+}
+
+// nearCollection returns the well-known collection (see [CollectionInfo]) that the collection is a near-miss of (if there is one).
+// A collection that IS a well-known collection is not a near-miss.
+func nearCollection(collection string) (string, bool) {
+	const maxDistance int = 2
+
+	var best string
+	var bestDistance int = maxDistance + 1
+
+	if _, found := CollectionInfo(collection); found {
+		return "", false
+	}
+
+	for _, info := range Collections() {
+		var known string = string(info.NSID)
+
+		var distance int = editDistance(strings.ToLower(collection), known)
+		if distance < bestDistance {
+			best = known
+			bestDistance = distance
+		}
+	}
+
+	return best, "" != best
+}
+
+// unknownCollectionNamespace returns the namespace (the NSID without its last segment, such as "app.bsky.feed") of the collection,
+// if the collection is not a well-known collection (see [CollectionInfo]) but other collections in the same namespace are.
+//
+// A collection in any other namespace (such as "com.example.fooBar") is not reported, since any app may define its own collections.
+func unknownCollectionNamespace(collection string) (string, bool) {
+	if _, found := CollectionInfo(collection); found {
+		return "", false
+	}
+
+	var index int = strings.LastIndexByte(collection, '.')
+	if index < 0 {
+		return "", false
+	}
+	var namespace string = collection[:index]
+
+	for _, info := range Collections() {
+		var known string = string(info.NSID)
+
+		if index := strings.LastIndexByte(known, '.'); 0 <= index && namespace == known[:index] {
+			return namespace, true
+		}
+	}
+
+	return "", false
+}
+
+// editDistance returns the number of single-byte insertions, deletions, substitutions, and transpositions of adjacent bytes
+// it takes to turn 'a' into 'b'.
+func editDistance(a string, b string) int {
+	var previous2 []int = make([]int, len(b)+1)
+	var previous  []int = make([]int, len(b)+1)
+	var current   []int = make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			var cost int = 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+
+			if 1 < i && 1 < j && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], previous2[j-2]+1)
+			}
+		}
+
+		previous2, previous, current = previous, current, previous2
+	}
+
+	return previous[len(b)]
+}
+
+// hasPrefixFold is like strings.HasPrefix, but ASCII case-insensitive.
+func hasPrefixFold(s string, prefix string) bool {
+	return len(prefix) <= len(s) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// message returns the error message without its "aturi: " prefix.
+func message(err error) string {
+	return strings.TrimPrefix(err.Error(), "aturi: ")
+}
+
+func quote(s string) string {
+	return "\"" + s + "\""
+}
+
+// FormatDiagnostics returns the diagnostics (as returned by [Lint]) formatted for a terminal,
+// with the problem in the AT-URI underlined with carets.
+//
+// For example:
+//
+//	error: the authority should not begin with an "@"
+//	  at://@reiver.bsky.social/app.bsky.feed.psot/3jui7kd54zh2y
+//	       ^
+//	  suggestion: at://reiver.bsky.social/app.bsky.feed.psot/3jui7kd54zh2y
+func FormatDiagnostics(uri string, diagnostics []Diagnostic) string {
+	var builder strings.Builder
+
+	for _, diagnostic := range diagnostics {
+		var begin int = max(0, min(diagnostic.Begin, len(uri)))
+		var end   int = max(begin, min(diagnostic.End, len(uri)))
+
+		var column int = utf8.RuneCountInString(uri[:begin])
+		var width  int = max(1, utf8.RuneCountInString(uri[begin:end]))
+
+		builder.WriteString(string(diagnostic.Severity))
+		builder.WriteString(": ")
+		builder.WriteString(diagnostic.Message)
+		builder.WriteString("\n  ")
+		builder.WriteString(uri)
+		builder.WriteString("\n  ")
+		builder.WriteString(strings.Repeat(" ", column))
+		builder.WriteString(strings.Repeat("^", width))
+		builder.WriteString("\n")
+
+		if "" != diagnostic.Suggestion {
+			builder.WriteString("  suggestion: ")
+			builder.WriteString(diagnostic.Suggestion)
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String()
+}
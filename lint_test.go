@@ -0,0 +1,274 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestLint(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		Expected []aturi.Diagnostic // only Begin, End, Severity, Kind, and Suggestion are compared
+	}{
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			URI: "at://reiver.bsky.social/com.example.fooBar/3jui7kd54zh2y?once=1#frag",
+		},
+
+
+
+		{
+			URI: "https://bsky.app/profile/reiver.bsky.social/post/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 0, End: 62, Severity: aturi.SeverityError, Kind: aturi.ErrorKindScheme, Suggestion: "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y"},
+			},
+		},
+		{
+			URI: "https://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 0, End: 8, Severity: aturi.SeverityError, Kind: aturi.ErrorKindScheme},
+			},
+		},
+		{
+			URI: "mailto:someone@example.com",
+			Expected: []aturi.Diagnostic{
+				{Begin: 0, End: 26, Severity: aturi.SeverityError, Kind: aturi.ErrorKindScheme},
+			},
+		},
+		{
+			URI: "at:/example.com/app.bsky.feed.post",
+			Expected: []aturi.Diagnostic{
+				{Begin: 0, End: 4, Severity: aturi.SeverityError, Kind: aturi.ErrorKindScheme, Suggestion: "at://example.com/app.bsky.feed.post"},
+			},
+		},
+		{
+			URI: "at:example.com",
+			Expected: []aturi.Diagnostic{
+				{Begin: 0, End: 3, Severity: aturi.SeverityError, Kind: aturi.ErrorKindScheme, Suggestion: "at://example.com"},
+			},
+		},
+		{
+			URI: "AT://example.com",
+			Expected: []aturi.Diagnostic{
+				{Begin: 0, End: 5, Severity: aturi.SeverityWarning, Kind: aturi.ErrorKindScheme, Suggestion: "at://example.com"},
+			},
+		},
+
+
+
+		{
+			URI: "at://@reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 5, End: 6, Severity: aturi.SeverityError, Kind: aturi.ErrorKindAuthority, Suggestion: "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y"},
+			},
+		},
+		{
+			URI: "at://did:PLC:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+			Expected: []aturi.Diagnostic{
+				{Begin: 9, End: 12, Severity: aturi.SeverityError, Kind: aturi.ErrorKindAuthority, Suggestion: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post"},
+			},
+		},
+		{
+			URI: "at://DID:plc:scewmn2pl3oz36mxme2b6czz",
+			Expected: []aturi.Diagnostic{
+				{Begin: 5, End: 8, Severity: aturi.SeverityError, Kind: aturi.ErrorKindAuthority, Suggestion: "at://did:plc:scewmn2pl3oz36mxme2b6czz"},
+			},
+		},
+		{
+			URI: "at://example.com./app.bsky.feed.post",
+			Expected: []aturi.Diagnostic{
+				{Begin: 16, End: 17, Severity: aturi.SeverityError, Kind: aturi.ErrorKindAuthority, Suggestion: "at://example.com/app.bsky.feed.post"},
+			},
+		},
+		{
+			URI: "at://localhost",
+			Expected: []aturi.Diagnostic{
+				{Begin: 5, End: 14, Severity: aturi.SeverityError, Kind: aturi.ErrorKindAuthority},
+			},
+		},
+		{
+			URI: "at:///app.bsky.feed.post",
+			Expected: []aturi.Diagnostic{
+				{Begin: 5, End: 5, Severity: aturi.SeverityError, Kind: aturi.ErrorKindAuthority},
+			},
+		},
+
+
+
+		{
+			URI: "at://example.com//3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 17, End: 17, Severity: aturi.SeverityError, Kind: aturi.ErrorKindPath},
+			},
+		},
+		{
+			URI: "at://example.com/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 16, End: 16, Severity: aturi.SeverityError, Kind: aturi.ErrorKindPath},
+			},
+		},
+		{
+			URI: "at://example.com/app.bsky.feed.psot/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 17, End: 35, Severity: aturi.SeverityWarning, Kind: aturi.ErrorKindCollection, Suggestion: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y"},
+			},
+		},
+		{
+			URI: "at://example.com/app.bsky.graph.folow",
+			Expected: []aturi.Diagnostic{
+				{Begin: 17, End: 37, Severity: aturi.SeverityWarning, Kind: aturi.ErrorKindCollection, Suggestion: "at://example.com/app.bsky.graph.follow"},
+			},
+		},
+		{
+			URI: "at://example.com/app.bsky.feed.thing/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 17, End: 36, Severity: aturi.SeverityWarning, Kind: aturi.ErrorKindCollection},
+			},
+		},
+		{
+			URI: "at://example.com/app.bsky.feed.thing.other/3jui7kd54zh2y",
+		},
+		{
+			URI: "at://example.com/com.example.thing/3jui7kd54zh2y",
+		},
+		{
+			URI: "at://example.com/app.bsky.feed.post-/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 17, End: 36, Severity: aturi.SeverityError, Kind: aturi.ErrorKindCollection, Suggestion: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y"},
+			},
+		},
+		{
+			URI: "at://example.com/app..bsky/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin: 17, End: 26, Severity: aturi.SeverityError, Kind: aturi.ErrorKindCollection},
+			},
+		},
+
+
+
+		{
+			URI: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y/",
+			Expected: []aturi.Diagnostic{
+				{Begin: 49, End: 50, Severity: aturi.SeverityError, Kind: aturi.ErrorKindPath, Suggestion: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y"},
+			},
+		},
+		{
+			URI: "at://example.com/",
+			Expected: []aturi.Diagnostic{
+				{Begin: 16, End: 17, Severity: aturi.SeverityError, Kind: aturi.ErrorKindPath, Suggestion: "at://example.com"},
+			},
+		},
+		{
+			URI: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y/extra",
+			Expected: []aturi.Diagnostic{
+				{Begin: 49, End: 55, Severity: aturi.SeverityError, Kind: aturi.ErrorKindPath},
+			},
+		},
+		{
+			URI: "at://example.com/app.bsky.feed.post/..",
+			Expected: []aturi.Diagnostic{
+				{Begin: 36, End: 38, Severity: aturi.SeverityError, Kind: aturi.ErrorKindRecordKey},
+			},
+		},
+
+
+
+		{
+			URI: "at:/@reiver.bsky.social./app.bsky.feed.psot/3jui7kd54zh2y",
+			Expected: []aturi.Diagnostic{
+				{Begin:  0, End:  4, Severity: aturi.SeverityError,   Kind: aturi.ErrorKindScheme,     Suggestion: "at://@reiver.bsky.social./app.bsky.feed.psot/3jui7kd54zh2y"},
+				{Begin:  4, End:  5, Severity: aturi.SeverityError,   Kind: aturi.ErrorKindAuthority,  Suggestion: "at:/reiver.bsky.social./app.bsky.feed.psot/3jui7kd54zh2y"},
+				{Begin: 23, End: 24, Severity: aturi.SeverityError,   Kind: aturi.ErrorKindAuthority,  Suggestion: "at:/@reiver.bsky.social/app.bsky.feed.psot/3jui7kd54zh2y"},
+				{Begin: 25, End: 43, Severity: aturi.SeverityWarning, Kind: aturi.ErrorKindCollection, Suggestion: "at:/@reiver.bsky.social./app.bsky.feed.post/3jui7kd54zh2y"},
+			},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual := aturi.Lint(test.URI)
+
+		if expected, actual := len(test.Expected), len(actual); expected != actual {
+			t.Errorf("For test #%d, the actual number of diagnostics is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			t.Logf("URI: %q", test.URI)
+			t.Logf("DIAGNOSTICS:\n%s", aturi.FormatDiagnostics(test.URI, aturi.Lint(test.URI)))
+			continue
+		}
+
+		for index, expected := range test.Expected {
+			var diagnostic aturi.Diagnostic = actual[index]
+			diagnostic.Message = ""
+
+			if expected != diagnostic {
+				t.Errorf("For test #%d and diagnostic #%d, the actual diagnostic is not what was expected.", testNumber, index)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", diagnostic)
+				t.Logf("URI: %q", test.URI)
+				continue
+			}
+
+			if "" == actual[index].Message {
+				t.Errorf("For test #%d and diagnostic #%d, expected a message but did not actually get one.", testNumber, index)
+				t.Logf("URI: %q", test.URI)
+				continue
+			}
+		}
+	}
+}
+
+func TestFormatDiagnostics(t *testing.T) {
+
+	const uri string = "at://@reiver.bsky.social/app.bsky.feed.psot/3jui7kd54zh2y"
+
+	const expected string =
+		"error: the authority should not begin with an \"@\"\n"+
+		"  at://@reiver.bsky.social/app.bsky.feed.psot/3jui7kd54zh2y\n"+
+		"       ^\n"+
+		"  suggestion: at://reiver.bsky.social/app.bsky.feed.psot/3jui7kd54zh2y\n"+
+		"warning: the collection is not a well-known NSID; did you mean \"app.bsky.feed.post\"?\n"+
+		"  at://@reiver.bsky.social/app.bsky.feed.psot/3jui7kd54zh2y\n"+
+		"                           ^^^^^^^^^^^^^^^^^^\n"+
+		"  suggestion: at://@reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y\n"
+
+	actual := aturi.FormatDiagnostics(uri, aturi.Lint(uri))
+
+	if expected != actual {
+		t.Error("The actual formatted diagnostics are not what was expected.")
+		t.Logf("EXPECTED:\n%s", expected)
+		t.Logf("ACTUAL:\n%s", actual)
+		return
+	}
+}
+
+func TestFormatDiagnostics_unicode(t *testing.T) {
+
+	const uri string = "at://bücher.example/app.bsky.feed.post/3jui7kd54zh2y/"
+
+	const expected string =
+		"error: an AT-URI should not end with a \"/\"\n"+
+		"  at://bücher.example/app.bsky.feed.post/3jui7kd54zh2y/\n"+
+		"                                                      ^\n"+
+		"  suggestion: at://bücher.example/app.bsky.feed.post/3jui7kd54zh2y\n"
+
+	var diagnostics []aturi.Diagnostic
+	for _, diagnostic := range aturi.Lint(uri) {
+		if aturi.ErrorKindPath == diagnostic.Kind {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	actual := aturi.FormatDiagnostics(uri, diagnostics)
+
+	if expected != actual {
+		t.Error("The actual formatted diagnostics are not what was expected.")
+		t.Logf("EXPECTED:\n%s", expected)
+		t.Logf("ACTUAL:\n%s", actual)
+		return
+	}
+}
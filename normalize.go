@@ -0,0 +1,28 @@
+package aturi
+
+import (
+	"strings"
+)
+
+// Normalize returns the normalized form of an AT-URI.
+//
+// Normalize:
+// makes the scheme lower-case,
+// makes a handle authority lower-case (a DID authority is left as is, because DIDs are case-sensitive),
+// and removes trailing slashes.
+// The collection, rkey, query, and fragment are left as is.
+//
+// Normalize returns an error if [Split] does.
+// Normalize does NOT do the checks that [ValidateStrict] does.
+func Normalize(uri string) (string, error) {
+	authority, collection, rkey, query, fragment, err := Split(uri)
+	if nil != err {
+		return "", err
+	}
+
+	if !strings.HasPrefix(authority, "did:") {
+		authority = strings.ToLower(authority)
+	}
+
+	return Join(authority, collection, rkey, query, fragment), nil
+}
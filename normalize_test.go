@@ -0,0 +1,61 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestNormalize(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		Expected string
+	}{
+		{
+			URI:      "at://example.com",
+			Expected: "at://example.com",
+		},
+		{
+			URI:      "AT://Example.COM",
+			Expected: "at://example.com",
+		},
+		{
+			URI:      "At://Example.COM/",
+			Expected: "at://example.com",
+		},
+		{
+			URI:      "at://did:plc:SCEWMN2pl3oz36mxme2b6czz",
+			Expected: "at://did:plc:SCEWMN2pl3oz36mxme2b6czz",
+		},
+		{
+			URI:      "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar",
+		},
+		{
+			URI:      "aT://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3JUI7kd54zh2y?Once=1#Path",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3JUI7kd54zh2y?Once=1#Path",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, err := aturi.Normalize(test.URI)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		expected := test.Expected
+
+		if expected != actual {
+			t.Errorf("For test #%d, the actual normalized AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package aturi
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// AuthorityKinds says which kinds of authority a [Parser] allows.
+// The kinds can be combined with '|'.
+type AuthorityKinds uint
+
+const (
+	AuthorityDID    AuthorityKinds = 1 << iota // the authority may be a (valid) DID
+	AuthorityHandle                            // the authority may be a (valid) handle
+)
+
+// Parser parses AT-URIs with configurable limits and permissiveness.
+//
+// The zero value of Parser behaves exactly like [Split] and [Validate]
+// (which are wrappers around a zero-value Parser).
+// Each option makes the Parser stricter than that.
+//
+// For example:
+//
+//	var parser = aturi.Parser{
+//		MaxLength:           2048,
+//		MaxRKeyLength:       512,
+//		Authorities:         aturi.AuthorityDID,
+//		DisallowQuery:       true,
+//		DisallowFragment:    true,
+//		CaseSensitiveScheme: true,
+//	}
+//	
+//	authority, collection, rkey, _, _, err := parser.Split(uri)
+//
+// A Parser is safe to use from many goroutines at the same time (as long as its fields are not changed while it is in use).
+type Parser struct {
+	// MaxLength is the most bytes the whole AT-URI may be.
+	// If MaxLength is 0 (or less), 8192 is used.
+	MaxLength int
+
+	// MaxAuthorityLength, MaxCollectionLength, MaxRKeyLength, MaxQueryLength, and MaxFragmentLength
+	// are the most bytes each component may be.
+	// If one is 0 (or less), that component has no limit of its own.
+	MaxAuthorityLength  int
+	MaxCollectionLength int
+	MaxRKeyLength       int
+	MaxQueryLength      int
+	MaxFragmentLength   int
+
+	// Authorities is the kinds of authority allowed.
+	// If Authorities is 0, the authority is not checked beyond what Split checks.
+	// Otherwise, the authority must be a valid DID or valid handle (as allowed).
+	Authorities AuthorityKinds
+
+	DisallowQuery         bool // whether an AT-URI with a query is an error
+	DisallowFragment      bool // whether an AT-URI with a fragment is an error
+	DisallowExtraSegments bool // whether an AT-URI with more path segments than a collection and an rkey is an error
+
+	// KnownCollections says whether a collection (if there is one) that is not a well-known collection (see [CollectionInfo]) is an error.
+	//
+	// It is off by default, since any app may define its own collections, and CollectionInfo only knows the collections it was generated from.
+	// Only turn it on where no other collection is expected (such as in a service that only handles app.bsky records).
+	// To just warn about a collection that is unknown (or mistyped), use [Lint].
+	KnownCollections bool
+
+	// CaseSensitiveScheme says whether the scheme must be a lower-case "at://".
+	// If false, the scheme is case-insensitive (so "AT://" is also allowed).
+	CaseSensitiveScheme bool
+
+	// Strict says whether the AT-URI must also pass [ValidateStrict]
+	// (the stricter rules used for 'at-uri' fields in AT-protocol records).
+	Strict bool
+
+	// Policy, if not nil, is checked after everything else.
+	// An AT-URI that the Policy does not allow gets an [*Error] whose kind is [ErrorKindPolicy].
+	Policy *Policy
+}
+
+// Split is like the [Split] func, but uses the options of the Parser.
+func (receiver Parser) Split(uri string) (authority string, collection string, rkey string, query string, fragment string, err error) {
+	if (Parser{}) == receiver {
+		return split(uri)
+	}
+
+	if "" == uri {
+		return "", "", "", "", "", newError(ErrorKindEmpty, uri, errEmptyURI)
+	}
+
+	if err := checkLength(uri, receiver.MaxLength); nil != err {
+		return "", "", "", "", "", err
+	}
+
+	if receiver.Strict {
+		if err := ValidateStrict(uri); nil != err {
+			return "", "", "", "", "", err
+		}
+	}
+
+	str, err := trimScheme(uri, receiver.CaseSensitiveScheme)
+	if nil != err {
+		return "", "", "", "", "", err
+	}
+
+	authority, collection, rkey, query, fragment = splitComponents(str)
+
+	// lengths
+	{
+		var limits = [...]struct{
+			name  string
+			kind  ErrorKind
+			value string
+			max   int
+		}{
+			{"authority",  ErrorKindAuthority,  authority,  receiver.MaxAuthorityLength},
+			{"collection", ErrorKindCollection, collection, receiver.MaxCollectionLength},
+			{"rkey",       ErrorKindRecordKey,  rkey,       receiver.MaxRKeyLength},
+			{"query",      ErrorKindQuery,      query,      receiver.MaxQueryLength},
+			{"fragment",   ErrorKindFragment,   fragment,   receiver.MaxFragmentLength},
+		}
+
+		for _, limit := range limits {
+			if 0 < limit.max && limit.max < len(limit.value) {
+				return "", "", "", "", "", newError(limit.kind, uri, erorr.Errorf("aturi: URI %q has a %s that is %d bytes long but it may not be more than %d bytes long", uri, limit.name, len(limit.value), limit.max))
+			}
+		}
+	}
+
+	if err := checkComponents(uri, authority, collection); nil != err {
+		return "", "", "", "", "", err
+	}
+
+	// authority
+	if 0 != receiver.Authorities {
+		if err := receiver.validateAuthority(authority); nil != err {
+			return "", "", "", "", "", newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has an authority %q that is not allowed: %w", uri, authority, err))
+		}
+	}
+
+	// collection
+	if receiver.KnownCollections && 0 < len(collection) {
+		if _, found := CollectionInfo(collection); !found {
+			return "", "", "", "", "", newError(ErrorKindCollection, uri, erorr.Errorf("aturi: URI %q has a collection %q that is not a well-known collection", uri, collection))
+		}
+	}
+
+	// rkey
+	if receiver.DisallowExtraSegments {
+		if err := checkSegments("URI", uri, rkey); nil != err {
+			return "", "", "", "", "", err
+		}
+	}
+
+	// query
+	if receiver.DisallowQuery {
+		var beforeFragment string = str
+		if hash := strings.IndexByte(str, '#'); 0 <= hash {
+			beforeFragment = str[:hash]
+		}
+
+		if strings.Contains(beforeFragment, "?") {
+			return "", "", "", "", "", newError(ErrorKindQuery, uri, erorr.Errorf("aturi: URI %q may not have a query", uri))
+		}
+	}
+
+	// fragment
+	if receiver.DisallowFragment && strings.Contains(str, "#") {
+		return "", "", "", "", "", newError(ErrorKindFragment, uri, erorr.Errorf("aturi: URI %q may not have a fragment", uri))
+	}
+
+	// policy
+	if err := receiver.Policy.check(uri, authority, collection); nil != err {
+		return "", "", "", "", "", err
+	}
+
+	return
+}
+
+// split is what a zero-value Parser does.
+// It skips all the checks that only options turn on, so that [Split] and [Validate] stay fast.
+func split(uri string) (authority string, collection string, rkey string, query string, fragment string, err error) {
+	if "" == uri {
+		return "", "", "", "", "", newError(ErrorKindEmpty, uri, errEmptyURI)
+	}
+
+	if err := checkLength(uri, 0); nil != err {
+		return "", "", "", "", "", err
+	}
+
+	str, err := trimScheme(uri, false)
+	if nil != err {
+		return "", "", "", "", "", err
+	}
+
+	authority, collection, rkey, query, fragment = splitComponents(str)
+
+	if err := checkComponents(uri, authority, collection); nil != err {
+		return "", "", "", "", "", err
+	}
+
+	return
+}
+
+// checkLength returns an error if the URI is more than 'max' bytes long.
+// If 'max' is 0 (or less), 8192 is used.
+func checkLength(uri string, max int) error {
+	if max <= 0 {
+		max = 8192 // == 8 kilobytes == 8 × 1 kilobyte == 8 × 1024 bytes == 8 × 2¹⁰ bytes
+	}
+
+	var length int = len(uri)
+
+	if max < length {
+		return newError(ErrorKindTooLong, uri, erorr.Errorf("aturi: URI is %d bytes long but an AT-URI may not be more than %d bytes long", length, max))
+	}
+
+	return nil
+}
+
+// trimScheme returns what comes after the "at://" of the URI.
+// It returns an error if the URI does not begin with "at://" (case-insensitively, unless 'caseSensitive' is true).
+func trimScheme(uri string, caseSensitive bool) (string, error) {
+	const prefix string = "at://"
+
+	var lenprefix int = len(prefix)
+	var lenuri int = len(uri)
+	if lenuri < lenprefix {
+		return "", newError(ErrorKindScheme, uri, erorr.Errorf("aturi: URI %q is not an at-uri because it does not begin with %q", uri, prefix))
+	}
+
+	var beginning string = uri[:lenprefix]
+
+	switch {
+	case prefix == beginning:
+		// nothing here
+	case caseSensitive:
+		return "", newError(ErrorKindScheme, uri, erorr.Errorf("aturi: URI %q does not begin with a lower-case %q", uri, prefix))
+	case !strings.EqualFold(beginning, prefix):
+		return "", newError(ErrorKindScheme, uri, erorr.Errorf("aturi: URI %q is not an at-uri because it does not begin with %q", uri, prefix))
+	}
+
+	return uri[lenprefix:], nil
+}
+
+// checkComponents does the checks on the authority and collection that every Parser does (including the zero-value Parser).
+func checkComponents(uri string, authority string, collection string) error {
+	// authority
+	{
+		if "" == authority {
+			return newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has an empty 'authority'", uri))
+		}
+
+		{
+			const disallowed string = "@"
+
+			if strings.Contains(authority, disallowed) {
+				return newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q may not have an %q in its authority %q", uri, disallowed, authority))
+			}
+		}
+	}
+
+	// collection
+	if 0 < len(collection) {
+		if err := checkCollection("URI", uri, collection); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate is like the [Validate] func, but uses the options of the Parser.
+func (receiver Parser) Validate(uri string) error {
+	_, _, _, _, _, err := receiver.Split(uri)
+	return err
+}
+
+// validateAuthority returns an error if the authority is not one of the kinds the Parser allows.
+func (receiver Parser) validateAuthority(authority string) error {
+	if strings.HasPrefix(authority, "did:") {
+		if 0 == receiver.Authorities&AuthorityDID {
+			return erorr.Errorf("aturi: authority %q is a DID, but DIDs are not allowed", authority)
+		}
+		return validateDID(authority)
+	}
+
+	if 0 == receiver.Authorities&AuthorityHandle {
+		return erorr.Errorf("aturi: authority %q is not a DID, but only DIDs are allowed", authority)
+	}
+	return validateHandle(authority)
+}
@@ -0,0 +1,297 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"errors"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestParser_zero(t *testing.T) {
+
+	var parser aturi.Parser
+
+	for testNumber, test := range splitTests {
+
+		authority, collection, rkey, query, fragment, err := parser.Split(test.URI)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected, actual := test.ExpectedAuthority, authority; expected != actual {
+			t.Errorf("For test #%d, the actual 'authority' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+		if expected, actual := test.ExpectedCollection, collection; expected != actual {
+			t.Errorf("For test #%d, the actual 'collection' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+		if expected, actual := test.ExpectedRKey, rkey; expected != actual {
+			t.Errorf("For test #%d, the actual 'rkey' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+		if expected, actual := test.ExpectedQuery, query; expected != actual {
+			t.Errorf("For test #%d, the actual 'query' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+		if expected, actual := test.ExpectedFragment, fragment; expected != actual {
+			t.Errorf("For test #%d, the actual 'fragment' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+	}
+}
+
+func TestParser_Validate(t *testing.T) {
+
+	tests := []struct{
+		Parser aturi.Parser
+		URI string
+		ExpectedKind aturi.ErrorKind // "" means valid
+	}{
+		{
+			Parser:       aturi.Parser{MaxLength: 35},
+			URI:          "at://example.com/app.bsky.feed.post",
+		},
+		{
+			Parser:       aturi.Parser{MaxLength: 35},
+			URI:          "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindTooLong,
+		},
+		{
+			Parser:       aturi.Parser{MaxLength: 16},
+			URI:          "at://"+strings.Repeat("a", 8192),
+			ExpectedKind: aturi.ErrorKindTooLong,
+		},
+
+
+
+		{
+			Parser:       aturi.Parser{MaxAuthorityLength: 11},
+			URI:          "at://example.com/app.bsky.feed.post",
+		},
+		{
+			Parser:       aturi.Parser{MaxAuthorityLength: 10},
+			URI:          "at://example.com/app.bsky.feed.post",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			Parser:       aturi.Parser{MaxCollectionLength: 10},
+			URI:          "at://example.com/app.bsky.feed.post",
+			ExpectedKind: aturi.ErrorKindCollection,
+		},
+		{
+			Parser:       aturi.Parser{MaxRKeyLength: 12},
+			URI:          "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			Parser:       aturi.Parser{MaxQueryLength: 3},
+			URI:          "at://example.com?once=1",
+			ExpectedKind: aturi.ErrorKindQuery,
+		},
+		{
+			Parser:       aturi.Parser{MaxFragmentLength: 3},
+			URI:          "at://example.com#frag",
+			ExpectedKind: aturi.ErrorKindFragment,
+		},
+
+
+
+		{
+			Parser:       aturi.Parser{Authorities: aturi.AuthorityDID},
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+		},
+		{
+			Parser:       aturi.Parser{Authorities: aturi.AuthorityDID},
+			URI:          "at://example.com/app.bsky.feed.post",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			Parser:       aturi.Parser{Authorities: aturi.AuthorityDID},
+			URI:          "at://did:PLC:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			Parser:       aturi.Parser{Authorities: aturi.AuthorityHandle},
+			URI:          "at://example.com/app.bsky.feed.post",
+		},
+		{
+			Parser:       aturi.Parser{Authorities: aturi.AuthorityHandle},
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			Parser:       aturi.Parser{Authorities: aturi.AuthorityHandle},
+			URI:          "at://localhost/app.bsky.feed.post",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			Parser:       aturi.Parser{Authorities: aturi.AuthorityDID|aturi.AuthorityHandle},
+			URI:          "at://example.com/app.bsky.feed.post",
+		},
+		{
+			Parser:       aturi.Parser{Authorities: aturi.AuthorityDID|aturi.AuthorityHandle},
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+		},
+
+
+
+		{
+			Parser:       aturi.Parser{DisallowQuery: true},
+			URI:          "at://example.com/app.bsky.feed.post#frag?ment",
+		},
+		{
+			Parser:       aturi.Parser{DisallowQuery: true},
+			URI:          "at://example.com/app.bsky.feed.post?once=1",
+			ExpectedKind: aturi.ErrorKindQuery,
+		},
+		{
+			Parser:       aturi.Parser{DisallowQuery: true},
+			URI:          "at://example.com?",
+			ExpectedKind: aturi.ErrorKindQuery,
+		},
+		{
+			Parser:       aturi.Parser{DisallowFragment: true},
+			URI:          "at://example.com/app.bsky.feed.post?once=1",
+		},
+		{
+			Parser:       aturi.Parser{DisallowFragment: true},
+			URI:          "at://example.com/app.bsky.feed.post#",
+			ExpectedKind: aturi.ErrorKindFragment,
+		},
+		{
+			Parser:       aturi.Parser{DisallowExtraSegments: true},
+			URI:          "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			Parser:       aturi.Parser{DisallowExtraSegments: true},
+			URI:          "at://example.com/app.bsky.feed.post/3jui7kd54zh2y/extra",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			Parser:       aturi.Parser{},
+			URI:          "at://example.com/app.bsky.feed.post/3jui7kd54zh2y/extra",
+		},
+
+
+
+		{
+			Parser:       aturi.Parser{KnownCollections: true},
+			URI:          "at://example.com",
+		},
+		{
+			Parser:       aturi.Parser{KnownCollections: true},
+			URI:          "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			Parser:       aturi.Parser{KnownCollections: true},
+			URI:          "at://example.com/com.example.fooBar/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindCollection,
+		},
+
+
+
+		{
+			Parser:       aturi.Parser{},
+			URI:          "AT://example.com",
+		},
+		{
+			Parser:       aturi.Parser{CaseSensitiveScheme: true},
+			URI:          "at://example.com",
+		},
+		{
+			Parser:       aturi.Parser{CaseSensitiveScheme: true},
+			URI:          "AT://example.com",
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+		{
+			Parser:       aturi.Parser{CaseSensitiveScheme: true},
+			URI:          "https://example.com",
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+
+
+
+		{
+			Parser:       aturi.Parser{Strict: true},
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			Parser:       aturi.Parser{Strict: true},
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			Parser:       aturi.Parser{Strict: true},
+			URI:          "AT://example.com",
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+		{
+			Parser:       aturi.Parser{Strict: true},
+			URI:          "at://not_a_handle/app.bsky.feed.post",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+		{
+			Parser:       aturi.Parser{Strict: true, MaxLength: 16},
+			URI:          "at://example.com/app.bsky.feed.post/",
+			ExpectedKind: aturi.ErrorKindTooLong,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		err := test.Parser.Validate(test.URI)
+
+		if "" == test.ExpectedKind {
+			if nil != err {
+				t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+				t.Logf("ERROR: (%T) %s", err, err)
+				t.Logf("URI: %q", test.URI)
+			}
+			continue
+		}
+
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		var aturiError *aturi.Error
+		if !errors.As(err, &aturiError) {
+			t.Errorf("For test #%d, expected the error to be an *aturi.Error but it was not.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected, actual := test.ExpectedKind, aturiError.Kind; expected != actual {
+			t.Errorf("For test #%d, the actual error kind is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("ERROR: %s", err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+	}
+}
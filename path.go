@@ -0,0 +1,194 @@
+package aturi
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+	"github.com/reiver/go-nsid"
+)
+
+// SplitPath returns the 'collection' and 'rkey' of a repo path.
+//
+// A repo path is what comes after the authority in an AT-URI to a record, such as "app.bsky.feed.post/3jui7kd54zh2y".
+// Repo paths are the keys of a repository's MST (Merkle Search Tree),
+// and are what "com.atproto.repo.applyWrites" and the firehose use.
+//
+// SplitPath enforces the MST key rules:
+// the path is at most 1024 bytes long,
+// has exactly 2 segments (separated by a single '/'),
+// and only has the characters 'A'-'Z', 'a'-'z', '0'-'9', '.', '-', '_', ':', '~' (and the '/').
+// On top of that, the collection must be a valid NSID, and the rkey must be a valid record-key (just like [ValidateStrict] requires).
+//
+// An invalid path is returned as an [*Error] (whose URI field is the path).
+//
+// For example:
+//
+//	collection, rkey, err := aturi.SplitPath("app.bsky.feed.post/3jui7kd54zh2y")
+//	if nil != err {
+//		return err
+//	}
+//	
+//	// collection == "app.bsky.feed.post"
+//	// rkey       == "3jui7kd54zh2y"
+func SplitPath(path string) (collection string, rkey string, err error) {
+	if "" == path {
+		return "", "", newError(ErrorKindEmpty, path, errEmptyPath)
+	}
+
+	{
+		const max int = 1024
+
+		var length int = len(path)
+
+		if max < length {
+			return "", "", newError(ErrorKindTooLong, path, erorr.Errorf("aturi: path is %d bytes long but a path may not be more than %d bytes long", length, max))
+		}
+	}
+
+	for index := 0; index < len(path); index++ {
+		var b byte = path[index]
+
+		switch {
+		case 'A' <= b && b <= 'Z':
+		case 'a' <= b && b <= 'z':
+		case '0' <= b && b <= '9':
+		case '.' == b, '-' == b, '_' == b, ':' == b, '~' == b, '/' == b:
+		default:
+			return "", "", newError(ErrorKindPath, path, erorr.Errorf("aturi: character №%d (%q) of path %q is not allowed", index, b, path))
+		}
+	}
+
+	collection, rkey, found := cutPath(path)
+	if !found {
+		return "", "", newError(ErrorKindPath, path, erorr.Errorf("aturi: path %q does not have both a collection and an rkey", path))
+	}
+
+	if err := checkSegments("path", path, rkey); nil != err {
+		return "", "", err
+	}
+
+	if err := checkCollection("path", path, collection); nil != err {
+		return "", "", err
+	}
+
+	if err := checkRecordKey("path", path, rkey); nil != err {
+		return "", "", err
+	}
+
+	return collection, rkey, nil
+}
+
+// cutPath splits a path (such as "app.bsky.feed.post/3jui7kd54zh2y") at its first "/" into its collection and rkey.
+//
+// Everything after the first "/" (including any other "/") is the rkey.
+// If there is no "/", the whole path is the collection, and 'found' is false (the same way as with strings.Cut).
+//
+// It is the one place that both [Split] (for what comes after the authority of an AT-URI) and [SplitPath] split a path.
+// cutPath does NOT validate anything.
+func cutPath(path string) (collection string, rkey string, found bool) {
+	if index := strings.IndexByte(path, '/'); 0 <= index {
+		return path[:index], path[index+1:], true
+	}
+	return path, "", false
+}
+
+// checkCollection returns an [*Error] if the collection is not a valid NSID.
+//
+// 'label' and 'subject' say what the collection is part of in the error, such as "URI" and the AT-URI, or "path" and the repo path.
+func checkCollection(label string, subject string, collection string) error {
+	if isNSID(collection) {
+		return nil
+	}
+
+	if err := nsid.Validate(collection); nil != err {
+		return newError(ErrorKindCollection, subject, erorr.Errorf("aturi: %s %q has a collection %q that is not a valid NSID: %w", label, subject, collection, err))
+	}
+	return nil
+}
+
+// checkSegments returns an [*Error] if the rkey (as returned by cutPath) has a "/" in it,
+// i.e., if the path has more segments than a collection and an rkey.
+//
+// 'label' and 'subject' are as for checkCollection.
+func checkSegments(label string, subject string, rkey string) error {
+	if strings.Contains(rkey, "/") {
+		return newError(ErrorKindPath, subject, erorr.Errorf("aturi: %s %q has more path segments than a collection and an rkey", label, subject))
+	}
+	return nil
+}
+
+// checkRecordKey returns an [*Error] if the rkey is not a valid record-key.
+//
+// 'label' and 'subject' are as for checkCollection.
+func checkRecordKey(label string, subject string, rkey string) error {
+	if err := validateRecordKey(rkey); nil != err {
+		return newError(ErrorKindRecordKey, subject, erorr.Errorf("aturi: %s %q has an rkey %q that is not a valid record-key: %w", label, subject, rkey, err))
+	}
+	return nil
+}
+
+// JoinPath returns the repo path made up of the 'collection' and 'rkey'.
+//
+// JoinPath is the inverse of [SplitPath].
+//
+// JoinPath does NOT validate the path.
+// To validate, call [SplitPath].
+func JoinPath(collection string, rkey string) string {
+	return collection + "/" + rkey
+}
+
+// FromPath returns the AT-URI to the record at the repo path in the repo of the DID.
+//
+// For example:
+//
+//	uri, err := aturi.FromPath("did:plc:scewmn2pl3oz36mxme2b6czz", "app.bsky.feed.post/3jui7kd54zh2y")
+//	if nil != err {
+//		return err
+//	}
+//	
+//	// uri == "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+func FromPath(did string, path string) (string, error) {
+	if err := validateDID(did); nil != err {
+		return "", erorr.Errorf("aturi: %q is not a valid DID: %w", did, err)
+	}
+
+	collection, rkey, err := SplitPath(path)
+	if nil != err {
+		return "", err
+	}
+
+	return Join(did, collection, rkey, "", ""), nil
+}
+
+// ToPath returns the DID and the repo path of an AT-URI to a record.
+//
+// It is the inverse of [FromPath].
+// The AT-URI must pass [ValidateStrict], its authority must be a DID (not a handle), and it must have both a collection and an rkey.
+//
+// For example:
+//
+//	did, path, err := aturi.ToPath("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y")
+//	if nil != err {
+//		return err
+//	}
+//	
+//	// did  == "did:plc:scewmn2pl3oz36mxme2b6czz"
+//	// path == "app.bsky.feed.post/3jui7kd54zh2y"
+func ToPath(uri string) (did string, path string, err error) {
+	if err := ValidateStrict(uri); nil != err {
+		return "", "", err
+	}
+
+	authority, collection, rkey, _, _, _ := Split(uri)
+
+	if !strings.HasPrefix(authority, "did:") {
+		return "", "", newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has a handle %q as its authority rather than a DID", uri, authority))
+	}
+
+	if "" == collection || "" == rkey {
+		return "", "", newError(ErrorKindPath, uri, erorr.Errorf("aturi: URI %q does not point to a record (it needs both a collection and an rkey)", uri))
+	}
+
+	// ValidateStrict has already checked everything that SplitPath would.
+	return authority, JoinPath(collection, rkey), nil
+}
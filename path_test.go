@@ -0,0 +1,227 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"errors"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestSplitPath(t *testing.T) {
+
+	tests := []struct{
+		Path string
+		ExpectedCollection string
+		ExpectedRKey string
+	}{
+		{
+			Path:               "app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedCollection: "app.bsky.feed.post",
+			ExpectedRKey:       "3jui7kd54zh2y",
+		},
+		{
+			Path:               "app.bsky.actor.profile/self",
+			ExpectedCollection: "app.bsky.actor.profile",
+			ExpectedRKey:       "self",
+		},
+		{
+			Path:               "com.example.foorBar/a.b-c_d:e~f",
+			ExpectedCollection: "com.example.foorBar",
+			ExpectedRKey:       "a.b-c_d:e~f",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		collection, rkey, err := aturi.SplitPath(test.Path)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("PATH: %q", test.Path)
+			continue
+		}
+
+		if expected, actual := test.ExpectedCollection, collection; expected != actual {
+			t.Errorf("For test #%d, the actual 'collection' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("PATH: %q", test.Path)
+			continue
+		}
+
+		if expected, actual := test.ExpectedRKey, rkey; expected != actual {
+			t.Errorf("For test #%d, the actual 'rkey' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("PATH: %q", test.Path)
+			continue
+		}
+
+		if expected, actual := test.Path, aturi.JoinPath(collection, rkey); expected != actual {
+			t.Errorf("For test #%d, the actual path from JoinPath() is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestSplitPath_fail(t *testing.T) {
+
+	tests := []struct{
+		Path string
+		ExpectedKind aturi.ErrorKind
+	}{
+		{
+			Path:         "",
+			ExpectedKind: aturi.ErrorKindEmpty,
+		},
+		{
+			Path:         "app.bsky.feed.post/" + strings.Repeat("a", 1006),
+			ExpectedKind: aturi.ErrorKindTooLong,
+		},
+		{
+			Path:         "app.bsky.feed.post",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			Path:         "app.bsky.feed.post/3jui7kd54zh2y/extra",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			Path:         "/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			Path:         "app.bsky.feed.post/3jui7kd54zh2y?once=1",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			Path:         "app.bsky.feed.post/3jui 7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			Path:         "app.bsky.feed.post/",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			Path:         "app.bsky.feed.post/..",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			Path:         "foorBar/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindCollection,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		_, _, err := aturi.SplitPath(test.Path)
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("PATH: %q", test.Path)
+			continue
+		}
+
+		var aturiError *aturi.Error
+		if !errors.As(err, &aturiError) {
+			t.Errorf("For test #%d, expected the error to be an %T but it actually is not.", testNumber, aturiError)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := test.ExpectedKind, aturiError.Kind; expected != actual {
+			t.Errorf("For test #%d, the actual error kind is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("ERROR: %s", err)
+			continue
+		}
+	}
+}
+
+func TestFromPath(t *testing.T) {
+
+	const did string = "did:plc:scewmn2pl3oz36mxme2b6czz"
+	const path string = "app.bsky.feed.post/3jui7kd54zh2y"
+	const uri string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+
+	{
+		actual, err := aturi.FromPath(did, path)
+		if nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+
+		if expected := uri; expected != actual {
+			t.Errorf("The actual AT-URI from FromPath() is not what was expected.")
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+		}
+	}
+
+	{
+		actualDID, actualPath, err := aturi.ToPath(uri)
+		if nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+
+		if expected, actual := did, actualDID; expected != actual {
+			t.Errorf("The actual DID from ToPath() is not what was expected.")
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+		}
+		if expected, actual := path, actualPath; expected != actual {
+			t.Errorf("The actual path from ToPath() is not what was expected.")
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+		}
+	}
+}
+
+func TestFromPath_fail(t *testing.T) {
+
+	tests := []struct{
+		DID  string
+		Path string
+	}{
+		{
+			DID:  "example.com",
+			Path: "app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			DID:  "did:plc:scewmn2pl3oz36mxme2b6czz",
+			Path: "app.bsky.feed.post",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if _, err := aturi.FromPath(test.DID, test.Path); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("DID:  %q", test.DID)
+			t.Logf("PATH: %q", test.Path)
+			continue
+		}
+	}
+}
+
+func TestToPath_fail(t *testing.T) {
+
+	tests := []string{
+		"at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?once=1",
+	}
+
+	for testNumber, uri := range tests {
+
+		if _, _, err := aturi.ToPath(uri); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", uri)
+			continue
+		}
+	}
+}
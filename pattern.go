@@ -0,0 +1,260 @@
+package aturi
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+	"github.com/reiver/go-nsid"
+)
+
+// Pattern is a compiled AT-URI pattern, such as:
+//
+//	at://*/app.bsky.feed.*/*
+//	at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/*
+//
+// A pattern has an authority, and optionally a collection and an rkey, just like an AT-URI.
+// Each of them may be a wildcard ("*"):
+//
+// The authority is either "*" (which matches any authority) or an exact authority.
+// A handle authority is matched case-insensitively (since handles are case-insensitive). A DID authority is matched exactly.
+//
+// The collection is matched a segment at a time (at the '.' boundaries of the NSID).
+// Each segment is either an exact segment or "*".
+// A "*" as the last segment matches 1 or more segments; anywhere else it matches exactly 1 segment.
+// So "app.bsky.feed.*" matches "app.bsky.feed.post" and "app.bsky.feed.like", and "*" on its own matches any collection.
+// A "*" may not be part of a segment (such as "app.bsky.feed.po*").
+//
+// The rkey is either "*" (which matches any rkey) or an exact rkey.
+//
+// A component that the pattern does not have must also be missing from the AT-URI.
+// So "at://*/app.bsky.feed.post" only matches AT-URIs that have NO rkey.
+//
+// A pattern may not have a query or fragment.
+// The query and fragment of the AT-URI being matched are ignored.
+//
+// A Pattern is safe to use from many goroutines at the same time.
+type Pattern struct {
+	raw string
+
+	authority   string // made canonical (see canonicalAuthority)
+	collection  []string
+	rkey        string
+
+	hasCollection bool
+	hasRKey       bool
+}
+
+const wildcard string = "*"
+
+// CompilePattern compiles an AT-URI pattern.
+func CompilePattern(pattern string) (*Pattern, error) {
+	if "" == pattern {
+		return nil, errEmptyPattern
+	}
+
+	var str string
+	{
+		const prefix string = "at://"
+
+		if len(pattern) < len(prefix) || !strings.EqualFold(pattern[:len(prefix)], prefix) {
+			return nil, erorr.Errorf("aturi: pattern %q does not begin with %q", pattern, prefix)
+		}
+
+		str = pattern[len(prefix):]
+	}
+
+	if strings.ContainsAny(str, "?#") {
+		return nil, erorr.Errorf("aturi: pattern %q may not have a query or fragment", pattern)
+	}
+
+	var compiled = Pattern{
+		raw: pattern,
+	}
+
+	var parts []string = strings.Split(str, "/")
+	if 3 < len(parts) {
+		return nil, erorr.Errorf("aturi: pattern %q has more components than an authority, a collection, and an rkey", pattern)
+	}
+
+	// authority
+	{
+		var authority string = parts[0]
+
+		switch {
+		case "" == authority:
+			return nil, erorr.Errorf("aturi: pattern %q has an empty authority", pattern)
+		case wildcard != authority && strings.Contains(authority, wildcard):
+			return nil, erorr.Errorf("aturi: pattern %q has an authority %q with a wildcard that is not the whole authority", pattern, authority)
+		case strings.Contains(authority, "@"):
+			return nil, erorr.Errorf("aturi: pattern %q may not have an %q in its authority %q", pattern, "@", authority)
+		}
+
+		compiled.authority = canonicalAuthority(authority)
+	}
+
+	// collection
+	if 2 <= len(parts) {
+		var collection string = parts[1]
+
+		switch {
+		case "" == collection:
+			return nil, erorr.Errorf("aturi: pattern %q has an empty collection", pattern)
+		case !strings.Contains(collection, wildcard):
+			if err := nsid.Validate(collection); nil != err {
+				return nil, erorr.Errorf("aturi: pattern %q has a collection %q that is not a valid NSID: %w", pattern, collection, err)
+			}
+		}
+
+		var segments []string = strings.Split(collection, ".")
+		for _, segment := range segments {
+			switch {
+			case "" == segment:
+				return nil, erorr.Errorf("aturi: pattern %q has a collection %q with an empty segment", pattern, collection)
+			case wildcard != segment && strings.Contains(segment, wildcard):
+				return nil, erorr.Errorf("aturi: pattern %q has a collection %q with a wildcard that is not a whole segment", pattern, collection)
+			}
+		}
+
+		compiled.collection = segments
+		compiled.hasCollection = true
+	}
+
+	// rkey
+	if 3 <= len(parts) {
+		var rkey string = parts[2]
+
+		switch {
+		case "" == rkey:
+			return nil, erorr.Errorf("aturi: pattern %q has an empty rkey", pattern)
+		case wildcard != rkey && strings.Contains(rkey, wildcard):
+			return nil, erorr.Errorf("aturi: pattern %q has an rkey %q with a wildcard that is not the whole rkey", pattern, rkey)
+		}
+
+		compiled.rkey = rkey
+		compiled.hasRKey = true
+	}
+
+	return &compiled, nil
+}
+
+// MustCompilePattern is like [CompilePattern] except it panic()s if there is an error.
+func MustCompilePattern(pattern string) *Pattern {
+	compiled, err := CompilePattern(pattern)
+	if nil != err {
+		panic(err)
+	}
+
+	return compiled
+}
+
+// String returns the pattern that was compiled.
+func (receiver *Pattern) String() string {
+	if nil == receiver {
+		return ""
+	}
+	return receiver.raw
+}
+
+// Match returns whether the AT-URI matches the pattern.
+//
+// Match does NOT validate the AT-URI (so that it does not allocate).
+// To validate, call [Validate].
+func (receiver *Pattern) Match(uri string) bool {
+	if nil == receiver {
+		return false
+	}
+
+	const prefix string = "at://"
+
+	if len(uri) < len(prefix) || !strings.EqualFold(uri[:len(prefix)], prefix) {
+		return false
+	}
+
+	authority, collection, rkey, _, _ := splitComponents(uri[len(prefix):])
+
+	return receiver.MatchComponents(authority, collection, rkey)
+}
+
+// MatchComponents is like [Pattern.Match] but takes the components of an AT-URI (as returned by [Split]).
+func (receiver *Pattern) MatchComponents(authority string, collection string, rkey string) bool {
+	if nil == receiver {
+		return false
+	}
+
+	if wildcard != receiver.authority && !matchAuthority(receiver.authority, authority) {
+		return false
+	}
+
+	if receiver.hasCollection != ("" != collection) {
+		return false
+	}
+	if receiver.hasCollection && !matchCollection(receiver.collection, collection) {
+		return false
+	}
+
+	if receiver.hasRKey != ("" != rkey) {
+		return false
+	}
+	if receiver.hasRKey && wildcard != receiver.rkey && receiver.rkey != rkey {
+		return false
+	}
+
+	return true
+}
+
+// matchAuthority returns whether canonicalAuthority(authority) is the (canonical) authority of a pattern,
+// without allocating (the way canonicalAuthority would for a handle with an upper-case letter).
+func matchAuthority(canonical string, authority string) bool {
+	if strings.HasPrefix(authority, "did:") {
+		return canonical == authority
+	}
+
+	// A canonical authority that is not a DID was made lower-case, so it has no upper-case letters.
+	return !hasUpperASCII(canonical) && strings.EqualFold(canonical, authority)
+}
+
+// hasUpperASCII returns whether the string has an 'A'-'Z'.
+func hasUpperASCII(str string) bool {
+	for index := 0; index < len(str); index++ {
+		if b := str[index]; 'A' <= b && b <= 'Z' {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCollection returns whether the collection matches the segments of a collection pattern.
+func matchCollection(segments []string, collection string) bool {
+	var rest string = collection
+	var exhausted bool
+
+	for index, segment := range segments {
+		if exhausted {
+			return false
+		}
+
+		if wildcard == segment && len(segments)-1 == index {
+			return true
+		}
+
+		var part string
+		{
+			var dot int = strings.IndexByte(rest, '.')
+			switch {
+			case dot < 0:
+				part = rest
+				rest = ""
+				exhausted = true
+			default:
+				part = rest[:dot]
+				rest = rest[dot+1:]
+			}
+		}
+
+		if wildcard != segment && segment != part {
+			return false
+		}
+	}
+
+	return exhausted
+}
@@ -0,0 +1,270 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestPattern_Match(t *testing.T) {
+
+	tests := []struct{
+		Pattern string
+		URI string
+		Expected bool
+	}{
+		{
+			Pattern: "at://*/app.bsky.feed.*/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: true,
+		},
+		{
+			Pattern: "at://*/app.bsky.feed.*/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like/3jui7kd54zh2y",
+			Expected: true,
+		},
+		{
+			Pattern: "at://*/app.bsky.feed.*/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/3jui7kd54zh2y",
+			Expected: false,
+		},
+		{
+			Pattern: "at://*/app.bsky.feed.*/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed/3jui7kd54zh2y",
+			Expected: false,
+		},
+		{
+			Pattern: "at://*/app.bsky.feed.*/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feedx.post/3jui7kd54zh2y",
+			Expected: false,
+		},
+		{
+			Pattern: "at://*/app.bsky.feed.*/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+			Expected: false,
+		},
+		{
+			Pattern: "at://*/app.bsky.*/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: true,
+		},
+		{
+			Pattern: "at://*/app.*.feed.post/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: true,
+		},
+		{
+			Pattern: "at://*/app.*.post/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: false,
+		},
+		{
+			Pattern: "at://*/*/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: true,
+		},
+		{
+			Pattern: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/*",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/3jui7kd54zh2y",
+			Expected: true,
+		},
+		{
+			Pattern: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/*",
+			URI:     "at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.graph.follow/3jui7kd54zh2y",
+			Expected: false,
+		},
+		{
+			Pattern: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self",
+			URI:     "AT://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self",
+			Expected: true,
+		},
+		{
+			Pattern: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self",
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/other",
+			Expected: false,
+		},
+		{
+			Pattern: "at://*",
+			URI:     "at://example.com",
+			Expected: true,
+		},
+		{
+			Pattern: "at://*",
+			URI:     "at://example.com/app.bsky.feed.post",
+			Expected: false,
+		},
+		{
+			Pattern: "at://*/*/*",
+			URI:     "at://example.com/app.bsky.feed.post/3jui7kd54zh2y?once=1#path",
+			Expected: true,
+		},
+		{
+			Pattern: "at://*/*/*",
+			URI:     "https://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: false,
+		},
+
+
+
+		{
+			Pattern: "at://Reiver.BSKY.social/*/*",
+			URI:     "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: true,
+		},
+		{
+			Pattern: "at://reiver.bsky.social/*/*",
+			URI:     "at://REIVER.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: true,
+		},
+		{
+			Pattern: "at://did:plc:scewmn2pl3oz36mxme2b6czz/*/*",
+			URI:     "at://did:plc:SCEWMN2PL3OZ36MXME2B6CZZ/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: false,
+		},
+		{
+			Pattern: "at://did:plc:SCEWMN2PL3OZ36MXME2B6CZZ/*/*",
+			URI:     "at://DID:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: false,
+		},
+		{
+			Pattern: "at://reiver.bsky.social/*/*",
+			URI:     "at://reiver.bsky.socials/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: false,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		pattern, err := aturi.CompilePattern(test.Pattern)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("PATTERN: %q", test.Pattern)
+			continue
+		}
+
+		{
+			expected := test.Expected
+			actual   := pattern.Match(test.URI)
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual result of matching is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %t", expected)
+				t.Logf("ACTUAL:   %t", actual)
+				t.Logf("PATTERN: %q", test.Pattern)
+				t.Logf("URI:     %q", test.URI)
+				continue
+			}
+		}
+	}
+}
+
+func TestCompilePattern_fail(t *testing.T) {
+
+	tests := []struct{
+		Pattern string
+		ExpectedError string
+	}{
+		{
+			Pattern: "",
+			ExpectedError: `aturi: empty pattern`,
+		},
+		{
+			Pattern: "https://*",
+			ExpectedError: `aturi: pattern "https://*" does not begin with "at://"`,
+		},
+		{
+			Pattern: "at://*/*/*?once=1",
+			ExpectedError: `aturi: pattern "at://*/*/*?once=1" may not have a query or fragment`,
+		},
+		{
+			Pattern: "at://*/*/*/*",
+			ExpectedError: `aturi: pattern "at://*/*/*/*" has more components than an authority, a collection, and an rkey`,
+		},
+		{
+			Pattern: "at:///*/*",
+			ExpectedError: `aturi: pattern "at:///*/*" has an empty authority`,
+		},
+		{
+			Pattern: "at://did:plc:*/*/*",
+			ExpectedError: `aturi: pattern "at://did:plc:*/*/*" has an authority "did:plc:*" with a wildcard that is not the whole authority`,
+		},
+		{
+			Pattern: "at://*/app.bsky.feed.po*/*",
+			ExpectedError: `aturi: pattern "at://*/app.bsky.feed.po*/*" has a collection "app.bsky.feed.po*" with a wildcard that is not a whole segment`,
+		},
+		{
+			Pattern: "at://*/app..*/*",
+			ExpectedError: `aturi: pattern "at://*/app..*/*" has a collection "app..*" with an empty segment`,
+		},
+		{
+			Pattern: "at://*//*",
+			ExpectedError: `aturi: pattern "at://*//*" has an empty collection`,
+		},
+		{
+			Pattern: "at://*/*/",
+			ExpectedError: `aturi: pattern "at://*/*/" has an empty rkey`,
+		},
+		{
+			Pattern: "at://*/*/3jui*",
+			ExpectedError: `aturi: pattern "at://*/*/3jui*" has an rkey "3jui*" with a wildcard that is not the whole rkey`,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		_, err := aturi.CompilePattern(test.Pattern)
+
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("PATTERN: %q", test.Pattern)
+			continue
+		}
+
+		{
+			expected := test.ExpectedError
+			actual := err.Error()
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual 'error' is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+				t.Logf("PATTERN: %q", test.Pattern)
+				continue
+			}
+		}
+	}
+}
+
+func TestPattern_Match_allocs(t *testing.T) {
+
+	for testNumber, test := range []struct{
+		Pattern *aturi.Pattern
+		URI string
+	}{
+		{
+			Pattern: aturi.MustCompilePattern("at://*/app.bsky.feed.*/*"),
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			Pattern: aturi.MustCompilePattern("at://reiver.bsky.social/*/*"),
+			URI:     "at://Reiver.BSKY.social/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+	} {
+		if !test.Pattern.Match(test.URI) {
+			t.Errorf("For test #%d, expected the URI to match but it actually did not.", testNumber)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		allocs := testing.AllocsPerRun(100, func() {
+			test.Pattern.Match(test.URI)
+		})
+
+		if 0 != allocs {
+			t.Errorf("For test #%d, expected matching to not allocate but it actually did %v allocations.", testNumber, allocs)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+	}
+}
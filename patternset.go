@@ -0,0 +1,273 @@
+package aturi
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// PatternSet is a set of compiled AT-URI patterns (see [Pattern]) that an AT-URI can be tested against all at once.
+//
+// The patterns are indexed by their authority, and by the segments of their collection (including wildcard segments),
+// so that testing an AT-URI only looks at the patterns that could possibly match it,
+// even when there are thousands of patterns.
+//
+// Add must not be called at the same time as anything else.
+// Once all the patterns are added, Match and Matches are safe to use from many goroutines at the same time.
+type PatternSet struct {
+	patterns []*Pattern
+
+	byAuthority  map[string]*patternBucket
+	anyAuthority patternBucket
+}
+
+// patternBucket holds the (indexes of the) patterns for one authority.
+type patternBucket struct {
+	noCollection []int            // patterns with no collection
+	byCollection map[string][]int // patterns with an exact collection (no wildcards)
+	wildcards    collectionTrie   // patterns with a wildcard in the collection, by the segments of their collection
+}
+
+func (receiver *patternBucket) add(pattern *Pattern, index int) {
+	if !pattern.hasCollection {
+		receiver.noCollection = append(receiver.noCollection, index)
+		return
+	}
+
+	for _, segment := range pattern.collection {
+		if wildcard == segment {
+			receiver.wildcards.add(pattern.collection, index)
+			return
+		}
+	}
+
+	if nil == receiver.byCollection {
+		receiver.byCollection = map[string][]int{}
+	}
+
+	var collection string = strings.Join(pattern.collection, ".")
+	receiver.byCollection[collection] = append(receiver.byCollection[collection], index)
+}
+
+// collectionTrie is a trie of collection patterns, keyed by their NSID segments,
+// so that finding the patterns that could match a collection does not depend on how many patterns there are
+// (even when they have wildcards).
+type collectionTrie struct {
+	children map[string]*collectionTrie // by the next segment, if it is an exact segment
+	wildcard *collectionTrie            // for a next segment of "*" that is not the last segment (and so matches exactly 1 segment)
+	end      []int                      // patterns whose collection ends here
+	rest     []int                      // patterns whose next segment is a last segment of "*" (and so matches 1 or more segments)
+}
+
+func (receiver *collectionTrie) add(segments []string, index int) {
+	var node *collectionTrie = receiver
+
+	for segmentNumber, segment := range segments {
+		switch {
+		case wildcard == segment && len(segments)-1 == segmentNumber:
+			node.rest = append(node.rest, index)
+			return
+		case wildcard == segment:
+			if nil == node.wildcard {
+				node.wildcard = &collectionTrie{}
+			}
+			node = node.wildcard
+		default:
+			if nil == node.children {
+				node.children = map[string]*collectionTrie{}
+			}
+			child, found := node.children[segment]
+			if !found {
+				child = &collectionTrie{}
+				node.children[segment] = child
+			}
+			node = child
+		}
+	}
+
+	node.end = append(node.end, index)
+}
+
+// Add compiles a pattern and adds it to the set.
+func (receiver *PatternSet) Add(pattern string) error {
+	compiled, err := CompilePattern(pattern)
+	if nil != err {
+		return err
+	}
+
+	receiver.AddPattern(compiled)
+	return nil
+}
+
+// AddPattern adds an already compiled pattern to the set.
+func (receiver *PatternSet) AddPattern(pattern *Pattern) {
+	if nil == pattern {
+		return
+	}
+
+	var index int = len(receiver.patterns)
+	receiver.patterns = append(receiver.patterns, pattern)
+
+	if wildcard == pattern.authority {
+		receiver.anyAuthority.add(pattern, index)
+		return
+	}
+
+	if nil == receiver.byAuthority {
+		receiver.byAuthority = map[string]*patternBucket{}
+	}
+
+	bucket, found := receiver.byAuthority[pattern.authority]
+	if !found {
+		bucket = &patternBucket{}
+		receiver.byAuthority[pattern.authority] = bucket
+	}
+
+	bucket.add(pattern, index)
+}
+
+// Len returns the number of patterns in the set.
+func (receiver *PatternSet) Len() int {
+	if nil == receiver {
+		return 0
+	}
+	return len(receiver.patterns)
+}
+
+// Match returns whether the AT-URI matches any of the patterns in the set.
+//
+// Like [Pattern.Match], Match does NOT validate the AT-URI, and does not allocate.
+func (receiver *PatternSet) Match(uri string) bool {
+	var matched bool
+
+	receiver.Matches(uri, func(*Pattern) bool {
+		matched = true
+		return false
+	})
+
+	return matched
+}
+
+// Matches calls fn for each pattern in the set that the AT-URI matches, in no particular order.
+// If fn returns false, Matches stops.
+//
+// Like [Pattern.Match], Matches does NOT validate the AT-URI.
+func (receiver *PatternSet) Matches(uri string, fn func(*Pattern) bool) {
+	if nil == receiver || nil == fn {
+		return
+	}
+
+	const prefix string = "at://"
+
+	if len(uri) < len(prefix) || !strings.EqualFold(uri[:len(prefix)], prefix) {
+		return
+	}
+
+	authority, collection, rkey, _, _ := splitComponents(uri[len(prefix):])
+
+	if bucket, found := receiver.authorityBucket(authority); found {
+		if !receiver.matchBucket(bucket, authority, collection, rkey, fn) {
+			return
+		}
+	}
+
+	receiver.matchBucket(&receiver.anyAuthority, authority, collection, rkey, fn)
+}
+
+// authorityBucket returns the bucket for canonicalAuthority(authority).
+//
+// It only makes the authority lower-case when it has to (when it is a handle with an upper-case letter),
+// and then does it in a buffer on the stack, so that it does not allocate.
+func (receiver *PatternSet) authorityBucket(authority string) (*patternBucket, bool) {
+	// A handle is at most 253 characters long.
+	const maxHandleLength int = 253
+
+	// (strings.ToLower does not allocate when there is nothing to make lower-case.)
+	if strings.HasPrefix(authority, "did:") || !hasUpperASCII(authority) {
+		bucket, found := receiver.byAuthority[canonicalAuthority(authority)]
+		return bucket, found
+	}
+
+	if maxHandleLength < len(authority) {
+		// Not a valid handle, so it does not matter that this allocates.
+		bucket, found := receiver.byAuthority[canonicalAuthority(authority)]
+		return bucket, found
+	}
+
+	var buffer [maxHandleLength]byte
+	for index := 0; index < len(authority); index++ {
+		var b byte = authority[index]
+		switch {
+		case 'A' <= b && b <= 'Z':
+			b += 'a' - 'A'
+		case utf8.RuneSelf <= b:
+			// Not a valid handle either.
+			bucket, found := receiver.byAuthority[canonicalAuthority(authority)]
+			return bucket, found
+		}
+		buffer[index] = b
+	}
+
+	bucket, found := receiver.byAuthority[string(buffer[:len(authority)])]
+	return bucket, found
+}
+
+// matchBucket calls fn for each pattern in the bucket that matches.
+// It returns false if fn did.
+func (receiver *PatternSet) matchBucket(bucket *patternBucket, authority string, collection string, rkey string, fn func(*Pattern) bool) bool {
+	if "" == collection {
+		return receiver.matchIndexes(bucket.noCollection, authority, collection, rkey, fn)
+	}
+
+	if !receiver.matchIndexes(bucket.byCollection[collection], authority, collection, rkey, fn) {
+		return false
+	}
+
+	return receiver.matchTrie(&bucket.wildcards, collection, false, authority, collection, rkey, fn)
+}
+
+// matchTrie calls fn for each pattern in the trie that matches, where 'rest' is what is left of the collection after the segments of the node.
+// 'done' is whether there is nothing left of the collection.
+// It returns false if fn did.
+func (receiver *PatternSet) matchTrie(node *collectionTrie, rest string, done bool, authority string, collection string, rkey string, fn func(*Pattern) bool) bool {
+	if done {
+		return receiver.matchIndexes(node.end, authority, collection, rkey, fn)
+	}
+
+	if !receiver.matchIndexes(node.rest, authority, collection, rkey, fn) {
+		return false
+	}
+
+	var segment, after string = rest, ""
+	var more bool
+	if dot := strings.IndexByte(rest, '.'); 0 <= dot {
+		segment, after, more = rest[:dot], rest[dot+1:], true
+	}
+
+	if child, found := node.children[segment]; found {
+		if !receiver.matchTrie(child, after, !more, authority, collection, rkey, fn) {
+			return false
+		}
+	}
+
+	if nil != node.wildcard {
+		if !receiver.matchTrie(node.wildcard, after, !more, authority, collection, rkey, fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchIndexes calls fn for each of the patterns (by index) that matches.
+// It returns false if fn did.
+func (receiver *PatternSet) matchIndexes(indexes []int, authority string, collection string, rkey string, fn func(*Pattern) bool) bool {
+	for _, index := range indexes {
+		var pattern *Pattern = receiver.patterns[index]
+
+		if pattern.MatchComponents(authority, collection, rkey) && !fn(pattern) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,248 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"fmt"
+	"sort"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestPatternSet(t *testing.T) {
+
+	var set aturi.PatternSet
+
+	for _, pattern := range []string{
+		"at://*/app.bsky.feed.*/*",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/*",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self",
+		"at://*/app.bsky.graph.block/*",
+		"at://example.com",
+		"at://*/com.example.*.thing",
+		"at://*/com.*",
+		"at://Reiver.BSKY.social/*/*",
+	} {
+		if err := set.Add(pattern); nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+	}
+
+	tests := []struct{
+		URI string
+		Expected []string
+	}{
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: []string{
+				"at://*/app.bsky.feed.*/*",
+			},
+		},
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/3jui7kd54zh2y",
+			Expected: []string{
+				"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/*",
+			},
+		},
+		{
+			URI: "at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.graph.follow/3jui7kd54zh2y",
+		},
+		{
+			URI: "at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.graph.block/3jui7kd54zh2y",
+			Expected: []string{
+				"at://*/app.bsky.graph.block/*",
+			},
+		},
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self",
+			Expected: []string{
+				"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.actor.profile/self",
+			},
+		},
+		{
+			URI: "at://example.com",
+			Expected: []string{
+				"at://example.com",
+			},
+		},
+		{
+			URI: "at://example.com/app.bsky.feed.like/3jui7kd54zh2y",
+			Expected: []string{
+				"at://*/app.bsky.feed.*/*",
+			},
+		},
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar.thing",
+			Expected: []string{
+				"at://*/com.*",
+				"at://*/com.example.*.thing",
+			},
+		},
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.thing",
+			Expected: []string{
+				"at://*/com.*",
+			},
+		},
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar.thing/3jui7kd54zh2y",
+		},
+		{
+			URI: "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: []string{
+				"at://*/app.bsky.feed.*/*",
+				"at://Reiver.BSKY.social/*/*",
+			},
+		},
+		{
+			URI: "at://REIVER.bsky.social/app.bsky.graph.follow/3jui7kd54zh2y",
+			Expected: []string{
+				"at://Reiver.BSKY.social/*/*",
+			},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var actual []string
+		set.Matches(test.URI, func(pattern *aturi.Pattern) bool {
+			actual = append(actual, pattern.String())
+			return true
+		})
+		sort.Strings(actual)
+
+		{
+			expected := fmt.Sprintf("%q", test.Expected)
+			actual   := fmt.Sprintf("%q", actual)
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual matching patterns are not what was expected.", testNumber)
+				t.Logf("EXPECTED: %s", expected)
+				t.Logf("ACTUAL:   %s", actual)
+				t.Logf("URI: %q", test.URI)
+				continue
+			}
+		}
+
+		{
+			expected := 0 < len(test.Expected)
+			actual   := set.Match(test.URI)
+
+			if expected != actual {
+				t.Errorf("For test #%d, the actual result of matching is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %t", expected)
+				t.Logf("ACTUAL:   %t", actual)
+				t.Logf("URI: %q", test.URI)
+				continue
+			}
+		}
+	}
+}
+
+// newBigPatternSet returns a PatternSet with thousands of patterns, like a firehose filter might have.
+func newBigPatternSet() *aturi.PatternSet {
+	var set aturi.PatternSet
+
+	for index := 0; index < 5000; index++ {
+		set.Add(fmt.Sprintf("at://did:plc:%024d/app.bsky.graph.follow/*", index))
+		set.Add(fmt.Sprintf("at://did:plc:%024d/app.bsky.feed.*/*", index))
+	}
+	set.Add("at://*/app.bsky.graph.block/*")
+	set.Add("at://alice.example.com/app.bsky.feed.post/*")
+
+	return &set
+}
+
+func TestPatternSet_Match_allocs(t *testing.T) {
+
+	var set *aturi.PatternSet = newBigPatternSet()
+
+	for testNumber, uri := range []string{
+		"at://did:plc:000000000000000000004999/app.bsky.feed.post/3jui7kd54zh2y",
+		"at://alice.example.com/app.bsky.feed.post/3jui7kd54zh2y",
+		"at://Alice.Example.COM/app.bsky.feed.post/3jui7kd54zh2y",
+	} {
+		if !set.Match(uri) {
+			t.Errorf("For test #%d, expected the URI to match but it actually did not.", testNumber)
+			t.Logf("URI: %q", uri)
+			continue
+		}
+
+		allocs := testing.AllocsPerRun(100, func() {
+			set.Match(uri)
+		})
+
+		if 0 != allocs {
+			t.Errorf("For test #%d, expected matching to not allocate but it actually did %v allocations.", testNumber, allocs)
+			t.Logf("URI: %q", uri)
+			continue
+		}
+	}
+}
+
+func BenchmarkPatternSet_Match(b *testing.B) {
+
+	var set *aturi.PatternSet = newBigPatternSet()
+
+	const uri string = "at://did:plc:000000000000000000004999/app.bsky.feed.post/3jui7kd54zh2y"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.Match(uri)
+	}
+}
+
+// newBigWildcardPatternSet returns a PatternSet with thousands of patterns whose collection has a wildcard,
+// like a firehose filter for many apps might have.
+func newBigWildcardPatternSet() *aturi.PatternSet {
+	var set aturi.PatternSet
+
+	for index := 0; index < 5000; index++ {
+		set.Add(fmt.Sprintf("at://*/com.example%d.*/*", index))
+		set.Add(fmt.Sprintf("at://*/com.example%d.*.thing/*", index))
+	}
+	set.Add("at://*/app.bsky.feed.*/*")
+
+	return &set
+}
+
+func TestPatternSet_Match_wildcardCollections(t *testing.T) {
+
+	var set *aturi.PatternSet = newBigWildcardPatternSet()
+
+	for _, uri := range []string{
+		"at://did:plc:000000000000000000004999/app.bsky.feed.post/3jui7kd54zh2y",
+		"at://did:plc:000000000000000000004999/com.example4999.foorBar/3jui7kd54zh2y",
+		"at://did:plc:000000000000000000004999/com.example4999.foorBar.thing/3jui7kd54zh2y",
+	} {
+		if !set.Match(uri) {
+			t.Errorf("Expected the URI to match but it actually did not: %q", uri)
+		}
+
+		allocs := testing.AllocsPerRun(100, func() {
+			set.Match(uri)
+		})
+
+		if 0 != allocs {
+			t.Errorf("Expected matching to not allocate but it actually did %v allocations.", allocs)
+		}
+	}
+
+	if set.Match("at://did:plc:000000000000000000004999/com.example5000.foorBar/3jui7kd54zh2y") {
+		t.Errorf("Did not expect the URI to match but it actually did.")
+	}
+}
+
+func BenchmarkPatternSet_Match_wildcardCollections(b *testing.B) {
+
+	var set *aturi.PatternSet = newBigWildcardPatternSet()
+
+	const uri string = "at://did:plc:000000000000000000004999/app.bsky.feed.post/3jui7kd54zh2y"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.Match(uri)
+	}
+}
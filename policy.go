@@ -0,0 +1,204 @@
+package aturi
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// Policy says which AT-URIs are acceptable, by their authority and collection, on top of them being valid.
+//
+// For example, to only accept AT-URIs whose authority is a did:plc or did:web DID, and that are not in a deny list:
+//
+//	denied, err := aturi.LoadDIDSet("denied-dids.txt")
+//	if nil != err {
+//		return err
+//	}
+//	
+//	var policy = aturi.Policy{
+//		AllowDIDMethods: []string{"plc", "web"},
+//		DenyHandles:     true,
+//		DenyDIDs:        denied,
+//	}
+//	
+//	authority, collection, rkey, query, fragment, err := policy.Split(uri)
+//
+// A Policy can also be used as the Policy of a [Parser]:
+//
+//	var parser = aturi.Parser{
+//		DisallowFragment: true,
+//		Policy:           &policy,
+//	}
+//
+// Each rule that is empty (or nil, or false) is not used.
+//
+// Before any rule is applied, the authority must be a valid DID (if it begins with a "did:", in any case) or a valid handle.
+// Otherwise a DID rule could be gotten around by changing the case of the DID (such as "DID:key:…" or "did:KEY:…"), which [Split] allows.
+// So the zero Policy allows every AT-URI whose authority is a valid DID or handle.
+//
+// Deny rules are checked before allow rules.
+// An AT-URI that a Policy rejects gets an [*Error] whose kind is [ErrorKindPolicy].
+type Policy struct {
+	// AllowDIDMethods, if not empty, is the DID methods (such as "plc" and "web") that a DID authority must use.
+	AllowDIDMethods []string
+
+	// DenyDIDMethods is the DID methods that a DID authority may not use.
+	DenyDIDMethods []string
+
+	// AllowDIDs, if not nil, is the DIDs that a DID authority must be one of.
+	AllowDIDs DIDSet
+
+	// DenyDIDs is the DIDs that a DID authority may not be.
+	DenyDIDs DIDSet
+
+	// DenyHandles says whether a handle authority is rejected (so that only DID authorities are allowed).
+	DenyHandles bool
+
+	// AllowHandleSuffixes, if not empty, is what a handle authority must match one of.
+	// "*.bsky.social" matches any handle that ends in ".bsky.social" (but not "bsky.social" itself).
+	// Anything else (such as "bsky.app") matches just that handle.
+	// Handles are matched case-insensitively.
+	AllowHandleSuffixes []string
+
+	// DenyHandleSuffixes is what a handle authority may not match (the same way as AllowHandleSuffixes).
+	DenyHandleSuffixes []string
+
+	// AllowCollectionPrefixes, if not empty, is the NSID prefixes (such as "app.bsky" or "app.bsky.feed") that a collection must begin with.
+	// A prefix only matches whole NSID segments, so "app.bsky" matches "app.bsky.feed.post" but not "app.bskyx.post".
+	// An AT-URI without a collection is not checked against the collection rules.
+	AllowCollectionPrefixes []string
+
+	// DenyCollectionPrefixes is the NSID prefixes (matched the same way as AllowCollectionPrefixes) that a collection may not begin with.
+	DenyCollectionPrefixes []string
+}
+
+// Split is like [Split], but also returns an error if the policy does not allow the AT-URI.
+//
+// Split is a wrapper around a [Parser] whose Policy field is the policy.
+// To use a policy together with other options (or with [ParseRefWith], [Template.MatchWith], and so on), set the Policy field of a [Parser].
+func (receiver *Policy) Split(uri string) (authority string, collection string, rkey string, query string, fragment string, err error) {
+	return Parser{Policy: receiver}.Split(uri)
+}
+
+// Validate is like [Validate], but also returns an error if the policy does not allow the AT-URI.
+func (receiver *Policy) Validate(uri string) error {
+	_, _, _, _, _, err := receiver.Split(uri)
+	return err
+}
+
+// Allow returns nil if the policy allows an AT-URI with the 'authority' and 'collection' (as returned by [Split]).
+// Otherwise it returns an [*Error] whose kind is [ErrorKindPolicy].
+//
+// Allow is for when the AT-URI was already split. It does NOT validate the collection.
+// (The authority is always validated, since the rules could be gotten around by an invalid one; see [Policy].)
+func (receiver *Policy) Allow(authority string, collection string) error {
+	return receiver.check(Join(authority, collection, "", "", ""), authority, collection)
+}
+
+func (receiver *Policy) check(uri string, authority string, collection string) error {
+	if nil == receiver {
+		return nil
+	}
+
+	var reject = func(format string, a ...any) error {
+		return newError(ErrorKindPolicy, uri, erorr.Errorf("aturi: URI %q is not allowed: "+format, append([]any{uri}, a...)...))
+	}
+
+	// authority
+	switch {
+	case hasPrefixFold(authority, "did:"):
+		if err := validateDID(authority); nil != err {
+			return reject("authority %q is not a valid DID: %w", authority, err)
+		}
+
+		var method string = didMethod(authority)
+
+		if contains(receiver.DenyDIDMethods, method) {
+			return reject("DID method %q is denied", method)
+		}
+		if receiver.DenyDIDs.Has(authority) {
+			return reject("DID %q is denied", authority)
+		}
+		if 0 < len(receiver.AllowDIDMethods) && !contains(receiver.AllowDIDMethods, method) {
+			return reject("DID method %q is not one of the allowed DID methods %q", method, receiver.AllowDIDMethods)
+		}
+		if nil != receiver.AllowDIDs && !receiver.AllowDIDs.Has(authority) {
+			return reject("DID %q is not one of the allowed DIDs", authority)
+		}
+	default:
+		if err := validateHandle(authority); nil != err {
+			return reject("authority %q is not a valid handle: %w", authority, err)
+		}
+
+		if receiver.DenyHandles {
+			return reject("authority %q is a handle, and only DIDs are allowed", authority)
+		}
+		if matchHandleSuffixes(receiver.DenyHandleSuffixes, authority) {
+			return reject("handle %q is denied", authority)
+		}
+		if 0 < len(receiver.AllowHandleSuffixes) && !matchHandleSuffixes(receiver.AllowHandleSuffixes, authority) {
+			return reject("handle %q does not match any of the allowed handles %q", authority, receiver.AllowHandleSuffixes)
+		}
+	}
+
+	// collection
+	if "" != collection {
+		if matchCollectionPrefixes(receiver.DenyCollectionPrefixes, collection) {
+			return reject("collection %q is denied", collection)
+		}
+		if 0 < len(receiver.AllowCollectionPrefixes) && !matchCollectionPrefixes(receiver.AllowCollectionPrefixes, collection) {
+			return reject("collection %q does not begin with any of the allowed prefixes %q", collection, receiver.AllowCollectionPrefixes)
+		}
+	}
+
+	return nil
+}
+
+// didMethod returns the method of a DID, such as "plc" for "did:plc:scewmn2pl3oz36mxme2b6czz".
+func didMethod(did string) string {
+	var str string = strings.TrimPrefix(did, "did:")
+
+	if index := strings.IndexByte(str, ':'); 0 <= index {
+		return str[:index]
+	}
+	return str
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHandleSuffixes returns whether the handle matches any of the handle suffixes (see Policy.AllowHandleSuffixes).
+func matchHandleSuffixes(suffixes []string, handle string) bool {
+	for _, suffix := range suffixes {
+		switch {
+		case strings.HasPrefix(suffix, "*."):
+			var dotSuffix string = suffix[1:]
+			if len(dotSuffix) < len(handle) && strings.EqualFold(handle[len(handle)-len(dotSuffix):], dotSuffix) {
+				return true
+			}
+		default:
+			if strings.EqualFold(handle, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchCollectionPrefixes returns whether the collection begins with any of the NSID prefixes (see Policy.AllowCollectionPrefixes).
+func matchCollectionPrefixes(prefixes []string, collection string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(strings.TrimSuffix(prefix, "*"), ".")
+
+		if collection == prefix || strings.HasPrefix(collection, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,338 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"errors"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+
+	denied, err := aturi.NewDIDSet("did:plc:ewvi7nxzyoun6zhxrhs64oiz")
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	allowed, err := aturi.NewDIDSet("did:plc:scewmn2pl3oz36mxme2b6czz")
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	tests := []struct{
+		Policy aturi.Policy
+		URI string
+		Allowed bool
+	}{
+		{
+			Policy:  aturi.Policy{},
+			URI:     "at://example.com/com.example.foorBar/3jui7kd54zh2y",
+			Allowed: true,
+		},
+
+
+
+		{
+			Policy:  aturi.Policy{AllowDIDMethods: []string{"plc", "web"}},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowDIDMethods: []string{"plc", "web"}},
+			URI:     "at://did:web:example.com",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowDIDMethods: []string{"plc", "web"}},
+			URI:     "at://did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{AllowDIDMethods: []string{"plc", "web"}},
+			URI:     "at://reiver.bsky.social",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowDIDMethods: []string{"plc", "web"}, DenyHandles: true},
+			URI:     "at://reiver.bsky.social",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{DenyDIDMethods: []string{"web"}},
+			URI:     "at://did:web:example.com",
+			Allowed: false,
+		},
+
+
+
+		{
+			Policy:  aturi.Policy{DenyDIDs: denied},
+			URI:     "at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{DenyDIDs: denied},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowDIDs: allowed},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowDIDs: allowed},
+			URI:     "at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{AllowDIDs: allowed, DenyDIDs: allowed},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+
+
+
+		{
+			Policy:  aturi.Policy{AllowHandleSuffixes: []string{"*.bsky.social"}},
+			URI:     "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowHandleSuffixes: []string{"*.bsky.social"}},
+			URI:     "at://Reiver.BSKY.Social/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowHandleSuffixes: []string{"*.bsky.social"}},
+			URI:     "at://bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{AllowHandleSuffixes: []string{"*.bsky.social"}},
+			URI:     "at://reiverbsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{AllowHandleSuffixes: []string{"*.bsky.social", "bsky.app"}},
+			URI:     "at://bsky.app/app.bsky.feed.generator/whats-hot",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowHandleSuffixes: []string{"*.bsky.social"}},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{DenyHandleSuffixes: []string{"*.spam.example"}},
+			URI:     "at://buy.spam.example/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+
+
+
+		{
+			Policy:  aturi.Policy{AllowCollectionPrefixes: []string{"app.bsky.feed"}},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowCollectionPrefixes: []string{"app.bsky.feed.*"}},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like/3jui7kd54zh2y",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{AllowCollectionPrefixes: []string{"app.bsky.feed"}},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{AllowCollectionPrefixes: []string{"app.bsky"}},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bskyx.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{AllowCollectionPrefixes: []string{"app.bsky"}},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+			Allowed: true,
+		},
+		{
+			Policy:  aturi.Policy{DenyCollectionPrefixes: []string{"app.bsky.graph"}},
+			URI:     "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.block/3jui7kd54zh2y",
+			Allowed: false,
+		},
+
+
+		{
+			Policy:  aturi.Policy{DenyDIDMethods: []string{"key"}},
+			URI:     "at://did:KEY:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{DenyDIDMethods: []string{"key"}},
+			URI:     "at://DID:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{AllowDIDMethods: []string{"plc"}},
+			URI:     "at://DID:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{DenyDIDs: denied},
+			URI:     "at://DID:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{DenyDIDs: denied},
+			URI:     "at://did:PLC:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{DenyDIDs: denied},
+			URI:     "at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{DenyHandleSuffixes: []string{"*.example.com"}},
+			URI:     "at://not_a_handle/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{},
+			URI:     "at://did:/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: false,
+		},
+		{
+			Policy:  aturi.Policy{},
+			URI:     "at://Reiver.Bsky.Social/app.bsky.feed.post/3jui7kd54zh2y",
+			Allowed: true,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		err := test.Policy.Validate(test.URI)
+
+		if test.Allowed {
+			if nil != err {
+				t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+				t.Logf("ERROR: (%T) %s", err, err)
+				t.Logf("URI: %q", test.URI)
+			}
+			continue
+		}
+
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		var aturiError *aturi.Error
+		if !errors.As(err, &aturiError) {
+			t.Errorf("For test #%d, expected the error to be an %T but it actually is not.", testNumber, aturiError)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := aturi.ErrorKindPolicy, aturiError.Kind; expected != actual {
+			t.Errorf("For test #%d, the actual error kind is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestPolicy_Split_invalid(t *testing.T) {
+
+	var policy aturi.Policy
+
+	_, _, _, _, _, err := policy.Split("at://example.com/foorBar")
+	if nil == err {
+		t.Fatalf("Expected an error but did not actually get one.")
+	}
+
+	var aturiError *aturi.Error
+	if !errors.As(err, &aturiError) {
+		t.Fatalf("Expected the error to be an %T but it actually is not: (%T) %s", aturiError, err, err)
+	}
+
+	if expected, actual := aturi.ErrorKindCollection, aturiError.Kind; expected != actual {
+		t.Errorf("The actual error kind is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+}
+
+func TestPolicy_parser(t *testing.T) {
+
+	var parser = aturi.Parser{
+		DisallowFragment: true,
+		Policy:           &aturi.Policy{DenyHandles: true},
+	}
+
+	tests := []struct{
+		URI string
+		ExpectedKind aturi.ErrorKind
+	}{
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			URI:          "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindPolicy,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y#frag",
+			ExpectedKind: aturi.ErrorKindFragment,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var errs = map[string]error{
+			"Parser.Validate": parser.Validate(test.URI),
+		}
+		{
+			_, err := aturi.ParseRefWith[aturi.FeedPost](parser, test.URI)
+			errs["ParseRefWith"] = err
+		}
+		{
+			_, matched := aturi.MustCompileTemplate("at://{did}/app.bsky.feed.post/{rkey}").MatchWith(parser, test.URI)
+			if matched != ("" == test.ExpectedKind) {
+				t.Errorf("For test #%d, the actual 'matched' is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %t", "" == test.ExpectedKind)
+				t.Logf("ACTUAL:   %t", matched)
+				t.Logf("URI: %q", test.URI)
+			}
+		}
+
+		for name, err := range errs {
+			if "" == test.ExpectedKind {
+				if nil != err {
+					t.Errorf("For test #%d and %s, did not expect an error but actually got one.", testNumber, name)
+					t.Logf("ERROR: (%T) %s", err, err)
+					t.Logf("URI: %q", test.URI)
+				}
+				continue
+			}
+
+			var aturiError *aturi.Error
+			if !errors.As(err, &aturiError) {
+				t.Errorf("For test #%d and %s, expected the error to be an %T but it actually is not.", testNumber, name, aturiError)
+				t.Logf("ERROR: (%T) %v", err, err)
+				continue
+			}
+
+			if expected, actual := test.ExpectedKind, aturiError.Kind; expected != actual {
+				t.Errorf("For test #%d and %s, the actual error kind is not what was expected.", testNumber, name)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+				continue
+			}
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package aturi
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// PseudonymKey is a secret key for a [Pseudonymizer], along with its key ID.
+//
+// The key ID is put into each pseudonym, so that after the key is rotated,
+// it is still known which key made a pseudonym (and older pseudonyms can still be decrypted).
+type PseudonymKey struct {
+	ID     string // 1 to 16 characters of 'a'-'z' or '0'-'9'
+	Secret []byte // at least 32 bytes
+}
+
+// Pseudonymizer replaces the authority of AT-URIs with pseudonyms, so that AT-URIs can be exported (such as to logs and analytics) without the real DIDs and handles.
+//
+// The collection and rkey are kept, and the result is still an AT-URI whose authority is a (made up) DID.
+// The query and fragment are dropped, since they can have DIDs, handles, and other AT-URIs in them (such as "?subject=at://did:plc:…").
+//
+// There are 2 modes:
+//
+// [Pseudonymizer.Pseudonymize] uses a keyed HMAC.
+// It cannot be reversed.
+// The same authority always gets the same pseudonym (for the same key), so pseudonymized AT-URIs can still be joined by account:
+//
+//	at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y
+//	→
+//	at://did:hmac:k1:…/app.bsky.feed.post/3jui7kd54zh2y
+//
+// [Pseudonymizer.Encrypt] uses an AEAD (AES-256-GCM), and is reversed with [Pseudonymizer.Decrypt].
+// It is also deterministic (the nonce is derived from the authority), so it too can be joined by account:
+//
+//	at://did:aead:k1:…/app.bsky.feed.post/3jui7kd54zh2y
+//
+// A handle authority is made lower-case first (since handles are case-insensitive). A DID authority is left as is.
+//
+// A Pseudonymizer is safe to use from many goroutines at the same time.
+type Pseudonymizer struct {
+	current *pseudonymKey
+	keys    map[string]*pseudonymKey
+}
+
+type pseudonymKey struct {
+	id       string
+	hmacKey  []byte
+	nonceKey []byte
+	aead     cipher.AEAD
+}
+
+const (
+	pseudonymMethodHMAC string = "hmac"
+	pseudonymMethodAEAD string = "aead"
+
+	pseudonymHMACSize int = 16 // bytes of the HMAC-SHA256 that are kept
+)
+
+// NewPseudonymizer returns a Pseudonymizer that makes pseudonyms with the 'current' key,
+// and can also decrypt pseudonyms made with any of the 'old' keys.
+func NewPseudonymizer(current PseudonymKey, old ...PseudonymKey) (*Pseudonymizer, error) {
+	var pseudonymizer = Pseudonymizer{
+		keys: map[string]*pseudonymKey{},
+	}
+
+	for _, key := range append([]PseudonymKey{current}, old...) {
+		derived, err := derivePseudonymKey(key)
+		if nil != err {
+			return nil, err
+		}
+
+		if _, found := pseudonymizer.keys[key.ID]; found {
+			return nil, erorr.Errorf("aturi: there is more than one pseudonym key with the key ID %q", key.ID)
+		}
+		pseudonymizer.keys[key.ID] = derived
+
+		if nil == pseudonymizer.current {
+			pseudonymizer.current = derived
+		}
+	}
+
+	return &pseudonymizer, nil
+}
+
+// derivePseudonymKey derives a separate key for each use from the secret.
+func derivePseudonymKey(key PseudonymKey) (*pseudonymKey, error) {
+	{
+		const max int = 16
+
+		if "" == key.ID || max < len(key.ID) {
+			return nil, erorr.Errorf("aturi: pseudonym key ID %q must be 1 to %d characters long", key.ID, max)
+		}
+
+		for index := 0; index < len(key.ID); index++ {
+			var b byte = key.ID[index]
+
+			switch {
+			case 'a' <= b && b <= 'z':
+			case '0' <= b && b <= '9':
+			default:
+				return nil, erorr.Errorf("aturi: character №%d (%q) of pseudonym key ID %q is not allowed", index, b, key.ID)
+			}
+		}
+	}
+
+	{
+		const min int = 32
+
+		if len(key.Secret) < min {
+			return nil, erorr.Errorf("aturi: pseudonym key %q has a secret that is %d bytes long but it must be at least %d bytes long", key.ID, len(key.Secret), min)
+		}
+	}
+
+	var derive = func(purpose string) []byte {
+		var mac = hmac.New(sha256.New, key.Secret)
+		mac.Write([]byte("aturi pseudonym " + purpose))
+		return mac.Sum(nil)
+	}
+
+	block, err := aes.NewCipher(derive("aead"))
+	if nil != err {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if nil != err {
+		return nil, err
+	}
+
+	return &pseudonymKey{
+		id:       key.ID,
+		hmacKey:  derive("hmac"),
+		nonceKey: derive("nonce"),
+		aead:     aead,
+	}, nil
+}
+
+// Pseudonymize returns the AT-URI with its authority replaced by a keyed HMAC pseudonym, and without its query and fragment.
+//
+// It returns an error if [Split] does.
+func (receiver *Pseudonymizer) Pseudonymize(uri string) (string, error) {
+	if nil == receiver || nil == receiver.current {
+		return "", errNilReceiver
+	}
+
+	authority, collection, rkey, _, _, err := Split(uri)
+	if nil != err {
+		return "", err
+	}
+
+	var key *pseudonymKey = receiver.current
+
+	var mac = hmac.New(sha256.New, key.hmacKey)
+	mac.Write([]byte(canonicalAuthority(authority)))
+	var sum []byte = mac.Sum(nil)[:pseudonymHMACSize]
+
+	return Join(pseudonymDID(pseudonymMethodHMAC, key.id, sum), collection, rkey, "", ""), nil
+}
+
+// Encrypt returns the AT-URI with its authority replaced by an encrypted (AEAD) pseudonym, and without its query and fragment.
+// [Pseudonymizer.Decrypt] can reverse the pseudonym (but not bring back the query and fragment).
+//
+// It returns an error if [Split] does.
+func (receiver *Pseudonymizer) Encrypt(uri string) (string, error) {
+	if nil == receiver || nil == receiver.current {
+		return "", errNilReceiver
+	}
+
+	authority, collection, rkey, _, _, err := Split(uri)
+	if nil != err {
+		return "", err
+	}
+
+	var key *pseudonymKey = receiver.current
+	var plaintext []byte = []byte(canonicalAuthority(authority))
+
+	var nonce []byte
+	{
+		var mac = hmac.New(sha256.New, key.nonceKey)
+		mac.Write(plaintext)
+		nonce = mac.Sum(nil)[:key.aead.NonceSize()]
+	}
+
+	var sealed []byte = key.aead.Seal(nonce, nonce, plaintext, []byte(key.id))
+
+	return Join(pseudonymDID(pseudonymMethodAEAD, key.id, sealed), collection, rkey, "", ""), nil
+}
+
+// Decrypt reverses [Pseudonymizer.Encrypt], and returns the original AT-URI (with its authority made canonical).
+//
+// The key ID in the pseudonym says which key to decrypt it with, so it can be any of the keys the Pseudonymizer was made with.
+func (receiver *Pseudonymizer) Decrypt(uri string) (string, error) {
+	if nil == receiver {
+		return "", errNilReceiver
+	}
+
+	authority, collection, rkey, query, fragment, err := Split(uri)
+	if nil != err {
+		return "", err
+	}
+
+	keyID, data, err := parsePseudonymDID(pseudonymMethodAEAD, authority)
+	if nil != err {
+		return "", newError(ErrorKindAuthority, uri, err)
+	}
+
+	key, found := receiver.keys[keyID]
+	if !found {
+		return "", newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has a pseudonym made with the unknown key %q", uri, keyID))
+	}
+
+	var nonceSize int = key.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has a pseudonym that is too short", uri))
+	}
+
+	plaintext, err := key.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(key.id))
+	if nil != err {
+		return "", newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has a pseudonym that could not be decrypted: %w", uri, err))
+	}
+
+	return Join(string(plaintext), collection, rkey, query, fragment), nil
+}
+
+// canonicalAuthority makes a handle lower-case (since handles are case-insensitive), so that the same account always gets the same pseudonym, matches the same patterns, and so on.
+// A DID is left as is.
+func canonicalAuthority(authority string) string {
+	if strings.HasPrefix(authority, "did:") {
+		return authority
+	}
+	return strings.ToLower(authority)
+}
+
+// pseudonymDID returns a pseudonym authority, such as "did:hmac:k1:…".
+func pseudonymDID(method string, keyID string, data []byte) string {
+	return "did:" + method + ":" + keyID + ":" + base32Lower.EncodeToString(data)
+}
+
+// parsePseudonymDID is the inverse of pseudonymDID.
+func parsePseudonymDID(method string, authority string) (keyID string, data []byte, err error) {
+	var prefix string = "did:" + method + ":"
+
+	if !strings.HasPrefix(authority, prefix) {
+		return "", nil, erorr.Errorf("aturi: authority %q is not a %q pseudonym", authority, method)
+	}
+
+	var str string = authority[len(prefix):]
+
+	var colon int = strings.IndexByte(str, ':')
+	if colon < 0 {
+		return "", nil, erorr.Errorf("aturi: authority %q is missing a pseudonym key ID", authority)
+	}
+
+	data, err = base32Lower.DecodeString(str[colon+1:])
+	if nil != err {
+		return "", nil, erorr.Errorf("aturi: authority %q has a pseudonym that is not valid base32: %w", authority, err)
+	}
+
+	return str[:colon], data, nil
+}
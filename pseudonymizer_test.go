@@ -0,0 +1,246 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"bytes"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+func testPseudonymizer(t *testing.T, current aturi.PseudonymKey, old ...aturi.PseudonymKey) *aturi.Pseudonymizer {
+	t.Helper()
+
+	pseudonymizer, err := aturi.NewPseudonymizer(current, old...)
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	return pseudonymizer
+}
+
+var (
+	testPseudonymKey1 = aturi.PseudonymKey{ID: "k1", Secret: bytes.Repeat([]byte{1}, 32)}
+	testPseudonymKey2 = aturi.PseudonymKey{ID: "k2", Secret: bytes.Repeat([]byte{2}, 32)}
+)
+
+func TestPseudonymizer_Pseudonymize(t *testing.T) {
+
+	pseudonymizer := testPseudonymizer(t, testPseudonymKey1)
+
+	const uri string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+
+	pseudonym, err := pseudonymizer.Pseudonymize(uri)
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	if err := aturi.ValidateStrict(pseudonym); nil != err {
+		t.Errorf("Expected the pseudonymized AT-URI to be valid but it actually is not: %s", err)
+		t.Logf("PSEUDONYM: %q", pseudonym)
+	}
+
+	authority, collection, rkey, _, _, _ := aturi.Split(pseudonym)
+	if !strings.HasPrefix(authority, "did:hmac:k1:") {
+		t.Errorf("Expected the authority to begin with %q but it actually is %q", "did:hmac:k1:", authority)
+	}
+	if strings.Contains(pseudonym, "scewmn2pl3oz36mxme2b6czz") {
+		t.Errorf("Did not expect the pseudonymized AT-URI to have the DID in it, but it actually does: %q", pseudonym)
+	}
+	if expected, actual := "app.bsky.feed.post", collection; expected != actual {
+		t.Errorf("The actual 'collection' is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+	if expected, actual := "3jui7kd54zh2y", rkey; expected != actual {
+		t.Errorf("The actual 'rkey' is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+
+	// The same account gets the same pseudonym, so that pseudonymized AT-URIs can be joined by account.
+	{
+		other, err := pseudonymizer.Pseudonymize("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like/3l3qo2vusxu2b")
+		if nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+
+		otherAuthority, _, _, _, _, _ := aturi.Split(other)
+		if authority != otherAuthority {
+			t.Errorf("Expected the same account to get the same pseudonym, but it actually did not: %q and %q", authority, otherAuthority)
+		}
+	}
+
+	{
+		otherKey, err := testPseudonymizer(t, testPseudonymKey2).Pseudonymize(uri)
+		if nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+
+		if pseudonym == otherKey {
+			t.Errorf("Expected a different key to give a different pseudonym, but it actually did not.")
+		}
+	}
+
+	{
+		lower, _ := pseudonymizer.Pseudonymize("at://reiver.bsky.social")
+		upper, _ := pseudonymizer.Pseudonymize("at://Reiver.BSKY.social")
+
+		if lower != upper {
+			t.Errorf("Expected a handle to be pseudonymized case-insensitively, but it actually was not: %q and %q", lower, upper)
+		}
+	}
+}
+
+func TestPseudonymizer_Encrypt(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		Expected string
+	}{
+		{
+			URI:      "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			URI:      "at://did:web:example.com",
+			Expected: "at://did:web:example.com",
+		},
+		{
+			URI:      "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y?once=1#twice",
+			Expected: "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+	}
+
+	old := testPseudonymizer(t, testPseudonymKey1)
+	rotated := testPseudonymizer(t, testPseudonymKey2, testPseudonymKey1)
+
+	for testNumber, test := range tests {
+
+		encrypted, err := old.Encrypt(test.URI)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if err := aturi.Validate(encrypted); nil != err {
+			t.Errorf("For test #%d, expected the encrypted AT-URI to be valid but it actually is not: %s", testNumber, err)
+			continue
+		}
+
+		if again, _ := old.Encrypt(test.URI); again != encrypted {
+			t.Errorf("For test #%d, expected encrypting to be deterministic, but it actually was not.", testNumber)
+			t.Logf("FIRST:  %q", encrypted)
+			t.Logf("SECOND: %q", again)
+		}
+
+		// After the key is rotated, pseudonyms from the old key can still be decrypted.
+		decrypted, err := rotated.Decrypt(encrypted)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := test.Expected, decrypted; expected != actual {
+			t.Errorf("For test #%d, the actual decrypted AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestPseudonymizer_queryFragment(t *testing.T) {
+
+	pseudonymizer := testPseudonymizer(t, testPseudonymKey1)
+
+	const uri string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?subject=did:plc:ewvi7nxzyoun6zhxrhs64oiz&reply=at://reiver.bsky.social/app.bsky.feed.post/3k#did:web:example.com"
+
+	for name, fn := range map[string]func(string) (string, error){
+		"Pseudonymize": pseudonymizer.Pseudonymize,
+		"Encrypt":      pseudonymizer.Encrypt,
+	} {
+		pseudonym, err := fn(uri)
+		if nil != err {
+			t.Errorf("For %s, did not expect an error but actually got one: (%T) %s", name, err, err)
+			continue
+		}
+
+		for _, identifier := range []string{"scewmn2pl3oz36mxme2b6czz", "ewvi7nxzyoun6zhxrhs64oiz", "reiver", "example.com"} {
+			if strings.Contains(pseudonym, identifier) {
+				t.Errorf("For %s, did not expect the pseudonymized AT-URI to have %q in it, but it actually does: %q", name, identifier, pseudonym)
+			}
+		}
+
+		if _, _, _, query, fragment, _ := aturi.Split(pseudonym); "" != query || "" != fragment {
+			t.Errorf("For %s, expected the query and fragment to be dropped, but they actually were not: %q", name, pseudonym)
+		}
+	}
+}
+
+func TestPseudonymizer_Decrypt_fail(t *testing.T) {
+
+	pseudonymizer := testPseudonymizer(t, testPseudonymKey1)
+
+	encrypted, err := testPseudonymizer(t, testPseudonymKey2).Encrypt("at://did:plc:scewmn2pl3oz36mxme2b6czz")
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	hmaced, err := pseudonymizer.Pseudonymize("at://did:plc:scewmn2pl3oz36mxme2b6czz")
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	tampered := strings.Replace(encrypted, "did:aead:k2:", "did:aead:k1:", 1)
+
+	tests := []string{
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz",
+		encrypted, // unknown key
+		hmaced,    // cannot be reversed
+		tampered,
+		"at://did:aead:k1:aaaa",
+	}
+
+	for testNumber, uri := range tests {
+
+		if _, err := pseudonymizer.Decrypt(uri); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", uri)
+			continue
+		}
+	}
+}
+
+func TestNewPseudonymizer_fail(t *testing.T) {
+
+	tests := []struct{
+		Current aturi.PseudonymKey
+		Old     []aturi.PseudonymKey
+	}{
+		{
+			Current: aturi.PseudonymKey{ID: "", Secret: bytes.Repeat([]byte{1}, 32)},
+		},
+		{
+			Current: aturi.PseudonymKey{ID: "K1", Secret: bytes.Repeat([]byte{1}, 32)},
+		},
+		{
+			Current: aturi.PseudonymKey{ID: "k1", Secret: bytes.Repeat([]byte{1}, 31)},
+		},
+		{
+			Current: testPseudonymKey1,
+			Old:     []aturi.PseudonymKey{testPseudonymKey1},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if _, err := aturi.NewPseudonymizer(test.Current, test.Old...); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			continue
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package aturi
+
+import (
+	"encoding"
+
+	"github.com/reiver/go-erorr"
+)
+
+// Collection is a marker type for a record type (collection), such as [FeedPost] or [GraphList].
+// It is used as the type parameter of [Ref].
+//
+// A marker type is an empty struct whose NSID method returns the NSID of the collection.
+// For example:
+//
+//	type ExampleThing struct{}
+//	
+//	func (ExampleThing) NSID() string { return "com.example.thing" }
+type Collection interface {
+	NSID() string
+}
+
+// Ref is an AT-URI to a record in the collection C.
+//
+// Because the collection is part of the type, the compiler catches mixed-up references.
+// For example, a Ref[FeedPost] cannot be used where a Ref[GraphList] is expected:
+//
+//	type Like struct {
+//		Subject aturi.Ref[aturi.FeedPost]
+//	}
+//	
+//	type ListItem struct {
+//		List aturi.Ref[aturi.GraphList]
+//	}
+//
+// The zero value of a Ref is an empty reference (see [Ref.IsZero]).
+//
+// Ref is marshaled to (and unmarshaled from) text (and so JSON) as its AT-URI.
+type Ref[C Collection] struct {
+	uri       string
+	authority string
+	rkey      string
+}
+
+var (
+	_ encoding.TextMarshaler   = Ref[FeedPost]{}
+	_ encoding.TextUnmarshaler = &Ref[FeedPost]{}
+)
+
+// ParseRef returns the Ref for the AT-URI.
+//
+// ParseRef returns an error if [ValidateStrict] does,
+// if the collection of the AT-URI is not the collection of C,
+// or if the AT-URI does not have an rkey.
+// So, the AT-URI may not have a query, a fragment, or more path segments than a collection and an rkey,
+// and its rkey must be a valid record-key.
+//
+// For example:
+//
+//	ref, err := aturi.ParseRef[aturi.FeedPost]("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y")
+func ParseRef[C Collection](uri string) (Ref[C], error) {
+	return ParseRefWith[C](Parser{}, uri)
+}
+
+// ParseRefWith is like [ParseRef], but also uses the [Parser] (and so its options and [Policy]).
+// The AT-URI must pass both the Parser and [ValidateStrict].
+func ParseRefWith[C Collection](parser Parser, uri string) (Ref[C], error) {
+	authority, collection, rkey, _, _, err := parser.Split(uri)
+	if nil != err {
+		return Ref[C]{}, err
+	}
+
+	if err := ValidateStrict(uri); nil != err {
+		return Ref[C]{}, err
+	}
+
+	var expected string = nsidOf[C]()
+
+	if expected != collection {
+		return Ref[C]{}, newError(ErrorKindCollection, uri, erorr.Errorf("aturi: URI %q has collection %q but a reference to a %q was expected", uri, collection, expected))
+	}
+	if "" == rkey {
+		return Ref[C]{}, newError(ErrorKindPath, uri, erorr.Errorf("aturi: URI %q does not point to a record (it has no rkey)", uri))
+	}
+
+	return Ref[C]{
+		uri:       uri,
+		authority: authority,
+		rkey:      rkey,
+	}, nil
+}
+
+// MustParseRef is like [ParseRef] except it panic()s if there is an error.
+func MustParseRef[C Collection](uri string) Ref[C] {
+	ref, err := ParseRef[C](uri)
+	if nil != err {
+		panic(err)
+	}
+
+	return ref
+}
+
+// nsidOf returns the NSID of the collection C.
+func nsidOf[C Collection]() string {
+	var collection C
+	return collection.NSID()
+}
+
+// IsZero returns whether the Ref is empty.
+func (receiver Ref[C]) IsZero() bool {
+	return "" == receiver.uri
+}
+
+// String returns the AT-URI.
+func (receiver Ref[C]) String() string {
+	return receiver.uri
+}
+
+// Authority returns the authority (a handle or DID) of the AT-URI.
+func (receiver Ref[C]) Authority() string {
+	return receiver.authority
+}
+
+// Collection returns the collection of the AT-URI, which is always the NSID of C.
+func (receiver Ref[C]) Collection() string {
+	return nsidOf[C]()
+}
+
+// RKey returns the rkey of the AT-URI.
+func (receiver Ref[C]) RKey() string {
+	return receiver.rkey
+}
+
+// MarshalText makes Ref fit the encoding.TextMarshaler interface.
+//
+// An empty Ref is marshaled as an empty string.
+func (receiver Ref[C]) MarshalText() ([]byte, error) {
+	return []byte(receiver.uri), nil
+}
+
+// UnmarshalText makes Ref fit the encoding.TextUnmarshaler interface.
+//
+// An empty string is unmarshaled as an empty Ref.
+func (receiver *Ref[C]) UnmarshalText(text []byte) error {
+	if nil == receiver {
+		return errNilReceiver
+	}
+
+	if 0 == len(text) {
+		*receiver = Ref[C]{}
+		return nil
+	}
+
+	ref, err := ParseRef[C](string(text))
+	if nil != err {
+		return err
+	}
+
+	*receiver = ref
+	return nil
+}
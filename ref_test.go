@@ -0,0 +1,210 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"encoding/json"
+	"errors"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestParseRef(t *testing.T) {
+
+	const uri string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+
+	ref, err := aturi.ParseRef[aturi.FeedPost](uri)
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	if expected, actual := uri, ref.String(); expected != actual {
+		t.Errorf("The actual 'uri' is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+	if expected, actual := "did:plc:scewmn2pl3oz36mxme2b6czz", ref.Authority(); expected != actual {
+		t.Errorf("The actual 'authority' is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+	if expected, actual := "app.bsky.feed.post", ref.Collection(); expected != actual {
+		t.Errorf("The actual 'collection' is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+	if expected, actual := "3jui7kd54zh2y", ref.RKey(); expected != actual {
+		t.Errorf("The actual 'rkey' is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+	if ref.IsZero() {
+		t.Errorf("Did not expect the ref to be zero, but it actually is.")
+	}
+}
+
+func TestParseRef_fail(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		ExpectedKind aturi.ErrorKind
+	}{
+		{
+			URI:          "",
+			ExpectedKind: aturi.ErrorKindEmpty,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedKind: aturi.ErrorKindCollection,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.listitem/3k7zlbwhwvz2o",
+			ExpectedKind: aturi.ErrorKindCollection,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+
+
+
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o/b/c",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o?q=1",
+			ExpectedKind: aturi.ErrorKindQuery,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o#frag",
+			ExpectedKind: aturi.ErrorKindFragment,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/a/b/c?q=1#frag",
+			ExpectedKind: aturi.ErrorKindQuery,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o/",
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/..",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7z%20lbwhwvz2o",
+			ExpectedKind: aturi.ErrorKindRecordKey,
+		},
+		{
+			URI:          "AT://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o",
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+		{
+			URI:          "at://x/app.bsky.graph.list/3k7zlbwhwvz2o",
+			ExpectedKind: aturi.ErrorKindAuthority,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		_, err := aturi.ParseRef[aturi.GraphList](test.URI)
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		var aturiError *aturi.Error
+		if !errors.As(err, &aturiError) {
+			t.Errorf("For test #%d, expected the error to be an %T but it actually is not.", testNumber, aturiError)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := test.ExpectedKind, aturiError.Kind; expected != actual {
+			t.Errorf("For test #%d, the actual error kind is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestRef_json(t *testing.T) {
+
+	type ListItem struct {
+		Subject string                      `json:"subject"`
+		List    aturi.Ref[aturi.GraphList] `json:"list"`
+		Pinned  aturi.Ref[aturi.FeedPost]  `json:"pinned"`
+	}
+
+	const data string = `{"subject":"did:plc:ewvi7nxzyoun6zhxrhs64oiz","list":"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.list/3k7zlbwhwvz2o","pinned":""}`
+
+	var item ListItem
+	if err := json.Unmarshal([]byte(data), &item); nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	if expected, actual := "3k7zlbwhwvz2o", item.List.RKey(); expected != actual {
+		t.Errorf("The actual 'rkey' is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+	if !item.Pinned.IsZero() {
+		t.Errorf("Expected the empty ref to be zero, but it actually is not.")
+	}
+
+	{
+		actual, err := json.Marshal(item)
+		if nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+
+		if expected := data; expected != string(actual) {
+			t.Errorf("The actual JSON is not what was expected.")
+			t.Logf("EXPECTED: %s", expected)
+			t.Logf("ACTUAL:   %s", actual)
+		}
+	}
+
+	{
+		const mixedUp string = `{"list":"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"}`
+
+		if err := json.Unmarshal([]byte(mixedUp), &item); nil == err {
+			t.Errorf("Expected an error when unmarshaling a post URI into a list ref, but did not actually get one.")
+		}
+	}
+}
+
+func TestCollection_NSID(t *testing.T) {
+
+	tests := []struct{
+		Collection aturi.Collection
+		Expected string
+	}{
+		{aturi.ActorProfile{},     "app.bsky.actor.profile"},
+		{aturi.FeedGenerator{},    "app.bsky.feed.generator"},
+		{aturi.FeedLike{},         "app.bsky.feed.like"},
+		{aturi.FeedPost{},         "app.bsky.feed.post"},
+		{aturi.FeedPostgate{},     "app.bsky.feed.postgate"},
+		{aturi.FeedRepost{},       "app.bsky.feed.repost"},
+		{aturi.FeedThreadgate{},   "app.bsky.feed.threadgate"},
+		{aturi.GraphBlock{},       "app.bsky.graph.block"},
+		{aturi.GraphFollow{},      "app.bsky.graph.follow"},
+		{aturi.GraphList{},        "app.bsky.graph.list"},
+		{aturi.GraphListblock{},   "app.bsky.graph.listblock"},
+		{aturi.GraphListitem{},    "app.bsky.graph.listitem"},
+		{aturi.GraphStarterpack{}, "app.bsky.graph.starterpack"},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.Expected, test.Collection.NSID(); expected != actual {
+			t.Errorf("For test #%d, the actual NSID is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
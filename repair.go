@@ -0,0 +1,240 @@
+package aturi
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/reiver/go-erorr"
+)
+
+// RepairRule is one of the rewrites that [Repair] can apply.
+type RepairRule string
+
+const (
+	RepairTrimSpace        RepairRule = "trim-space"        // removes whitespace from the beginning and end
+	RepairUnwrap           RepairRule = "unwrap"            // removes matching brackets or quotes from around the whole thing, such as "<…>", "(…)", "[…]", `"…"`, "'…'", "`…`", "“…”", "‘…’", or "«…»"
+	                                                        // (or just the opening one, if the closing one is missing)
+	RepairTrimPunctuation  RepairRule = "trim-punctuation"  // removes trailing punctuation that is probably part of the prose around it (the same way [Extract] does)
+	RepairURLDecode        RepairRule = "url-decode"        // URL-decodes (percent-decodes) the whole thing, if its scheme is URL-encoded (such as "at%3A%2F%2F…") and it has no query or fragment
+	RepairRemoveSpace      RepairRule = "remove-space"      // removes whitespace next to a "/" or ":" (an AT-URI can never have whitespace in it)
+	RepairSchemeSlashes    RepairRule = "scheme-slashes"    // turns "at:" or "at:/" (without 2 slashes) into "at://"
+)
+
+// Change is a rewrite that [Repair] applied.
+type Change struct {
+	Rule   RepairRule
+	Before string // what it was before the rewrite
+	After  string // what it was after the rewrite
+}
+
+// Repair tries to turn messy input (such as from an import pipeline, or text someone copied-and-pasted) into a valid AT-URI.
+//
+// It only applies these rewrites (in this order):
+//
+//	1. trim-space:       removes whitespace from the beginning and end.
+//	2. unwrap:           removes matching brackets or quotes from around the whole thing.
+//	3. trim-punctuation: removes trailing punctuation, such as the '.' at the end of a sentence.
+//	                     (Steps 1–3 repeat until none of them change anything, so that "<at://…>." works.)
+//	4. url-decode:       URL-decodes the whole thing, if its scheme is URL-encoded (such as "at%3A%2F%2F…").
+//	                     (But not if that would give a query or fragment, since then what an escape meant is ambiguous.)
+//	5. remove-space:     removes whitespace next to a "/" or ":", such as in "at:// did:plc:… / app.bsky.feed.post".
+//	                     Whitespace anywhere else (such as in "3jui7 kd54zh2y") is an error, since it is not clear whether to join the pieces.
+//	6. scheme-slashes:   turns "at:" or "at:/" (without 2 slashes) into "at://".
+//
+// Repair returns each change it applied (in the order it applied them), so that the changes can be audited.
+//
+// Repair only succeeds if the result passes [Validate].
+// If it does not (or if remove-space finds whitespace inside a component), Repair returns "", the changes it applied, and the error.
+//
+// For example:
+//
+//	fixed, changes, err := aturi.Repair(`  <at:did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y>. `)
+//	if nil != err {
+//		return err
+//	}
+//	
+//	// fixed == "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+//	
+//	for _, change := range changes {
+//		log.Printf("%s: %q → %q", change.Rule, change.Before, change.After)
+//	}
+func Repair(input string) (fixed string, changes []Change, err error) {
+	var str string = input
+
+	apply := func(rule RepairRule, fn func(string) string) bool {
+		var after string = fn(str)
+		if after == str {
+			return false
+		}
+
+		changes = append(changes, Change{
+			Rule:   rule,
+			Before: str,
+			After:  after,
+		})
+		str = after
+		return true
+	}
+
+	for {
+		var changed bool
+		changed = apply(RepairTrimSpace, trimSpace) || changed
+		changed = apply(RepairUnwrap, unwrap) || changed
+		changed = apply(RepairTrimPunctuation, trimProse) || changed
+		if !changed {
+			break
+		}
+	}
+
+	apply(RepairURLDecode, urlDecode)
+	{
+		after, err := removeSpace(str)
+		if nil != err {
+			return "", changes, err
+		}
+		apply(RepairRemoveSpace, func(string) string { return after })
+	}
+	apply(RepairSchemeSlashes, schemeSlashes)
+
+	if err := Validate(str); nil != err {
+		return "", changes, err
+	}
+
+	return str, changes, nil
+}
+
+func trimSpace(str string) string {
+	return strings.TrimFunc(str, unicode.IsSpace)
+}
+
+// wrappers is the pairs of brackets and quotes that unwrap removes.
+var wrappers = [...]struct{
+	open  rune
+	close rune
+}{
+	{'<', '>'},
+	{'(', ')'},
+	{'[', ']'},
+	{'{', '}'},
+	{'"', '"'},
+	{'\'', '\''},
+	{'`', '`'},
+	{'“', '”'},
+	{'‘', '’'},
+	{'«', '»'},
+	{'‹', '›'},
+	{'„', '“'},
+	{'「', '」'},
+}
+
+// unwrap removes one pair of matching brackets or quotes from around the whole string.
+//
+// If the closing bracket or quote is missing (such as when it was removed as trailing punctuation),
+// the opening one is still removed, but only if the closing one is nowhere in the string,
+// and what comes after the opening one begins with "at" (so that it is probably an AT-URI).
+func unwrap(str string) string {
+	first, firstSize := utf8.DecodeRuneInString(str)
+	last, lastSize := utf8.DecodeLastRuneInString(str)
+
+	if len(str) < firstSize+lastSize {
+		return str
+	}
+
+	for _, wrapper := range wrappers {
+		if wrapper.open != first {
+			continue
+		}
+
+		switch {
+		case wrapper.close == last:
+			return str[firstSize:len(str)-lastSize]
+		case !strings.ContainsRune(str[firstSize:], wrapper.close) && hasPrefixFold(str[firstSize:], "at"):
+			return str[firstSize:]
+		}
+	}
+
+	return str
+}
+
+// urlDecode URL-decodes the string, if it begins with a URL-encoded "at:".
+//
+// It only does if the decoded string is just a scheme and a path (an authority, collection, and rkey).
+// If the decoded string has a query or fragment (or still has a '%'), an escape might have been decoded into a delimiter it was not meant to be
+// (such as an escaped '?' or '&' that was part of a query value),
+// so the string is left as it is.
+func urlDecode(str string) string {
+	if !hasPrefixFold(str, "at%3a") {
+		return str
+	}
+
+	decoded, err := url.PathUnescape(str)
+	if nil != err {
+		return str
+	}
+
+	if strings.ContainsAny(decoded, "?#%") {
+		return str
+	}
+
+	return decoded
+}
+
+// removeSpace removes each run of whitespace that is next to a "/" or ":" (such as in "at:// example.com / app.bsky.feed.post").
+//
+// It returns an error if a run of whitespace is anywhere else (such as in "3jui7 kd54zh2y"),
+// since then the whitespace is inside a component, and it is not clear whether the pieces on either side of it belong together.
+func removeSpace(str string) (string, error) {
+	if strings.IndexFunc(str, unicode.IsSpace) < 0 {
+		return str, nil
+	}
+
+	var isDelimiter = func(r rune) bool {
+		return '/' == r || ':' == r
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(str))
+
+	var rest string = str
+	for {
+		var begin int = strings.IndexFunc(rest, unicode.IsSpace)
+		if begin < 0 {
+			builder.WriteString(rest)
+			break
+		}
+
+		var end int = len(rest)
+		if index := strings.IndexFunc(rest[begin:], func(r rune) bool { return !unicode.IsSpace(r) }); 0 <= index {
+			end = begin + index
+		}
+
+		before, _ := utf8.DecodeLastRuneInString(rest[:begin])
+		after, _ := utf8.DecodeRuneInString(rest[end:])
+
+		if !isDelimiter(before) && !isDelimiter(after) {
+			var offset int = len(str) - len(rest)
+			return "", erorr.Errorf("aturi: %q has whitespace inside a component (at byte %d), so it cannot be safely repaired", str, offset+begin)
+		}
+
+		builder.WriteString(rest[:begin])
+		rest = rest[end:]
+	}
+
+	return builder.String(), nil
+}
+
+// schemeSlashes turns "at:" or "at:/" (without 2 slashes) at the beginning into "at://".
+func schemeSlashes(str string) string {
+	const scheme string = "at:"
+
+	if !hasPrefixFold(str, scheme) || hasPrefixFold(str, "at://") {
+		return str
+	}
+
+	var rest string = str[len(scheme):]
+	rest = strings.TrimPrefix(rest, "/")
+
+	return "at://" + rest
+}
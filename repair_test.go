@@ -0,0 +1,230 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestRepair(t *testing.T) {
+
+	tests := []struct{
+		Input string
+		Expected string
+		ExpectedRules []aturi.RepairRule
+	}{
+		{
+			Input:    "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			Input:         "  at://example.com/app.bsky.feed.post/3jui7kd54zh2y\r\n",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairTrimSpace},
+		},
+		{
+			Input:         "<at://example.com/app.bsky.feed.post/3jui7kd54zh2y>",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairUnwrap},
+		},
+		{
+			Input:         `"at://example.com/app.bsky.feed.post/3jui7kd54zh2y"`,
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairUnwrap},
+		},
+		{
+			Input:         "“at://example.com/app.bsky.feed.post/3jui7kd54zh2y”",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairUnwrap},
+		},
+		{
+			Input:         "(at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairUnwrap},
+		},
+		{
+			Input:         "at://example.com/app.bsky.feed.post/3jui7kd54zh2y.",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairTrimPunctuation},
+		},
+		{
+			Input:         " (<at://example.com/app.bsky.feed.post/3jui7kd54zh2y>), ",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairTrimSpace, aturi.RepairTrimPunctuation, aturi.RepairUnwrap, aturi.RepairUnwrap},
+		},
+		{
+			Input:         "at%3A%2F%2Fdid%3Aplc%3Ascewmn2pl3oz36mxme2b6czz%2Fapp.bsky.feed.post%2F3jui7kd54zh2y",
+			Expected:      "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairURLDecode},
+		},
+		{
+			Input:         "AT%3a%2f%2fexample.com",
+			Expected:      "AT://example.com",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairURLDecode},
+		},
+		{
+			// an encoded query is not decoded, because the scheme is not encoded
+			Input:         "at://example.com?q=a%20b",
+			Expected:      "at://example.com?q=a%20b",
+		},
+		{
+			Input:         "at://example.com/app.bsky.feed.post/ 3jui7kd54zh2y",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairRemoveSpace},
+		},
+		{
+			Input:         "at:// did:plc: scewmn2pl3oz36mxme2b6czz /app.bsky.feed.post/\t3jui7kd54zh2y",
+			Expected:      "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairRemoveSpace},
+		},
+		{
+			Input:         "at:example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairSchemeSlashes},
+		},
+		{
+			Input:         "AT:/example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairSchemeSlashes},
+		},
+		{
+			Input:         "  <at:did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y>. ",
+			Expected:      "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairTrimSpace, aturi.RepairTrimPunctuation, aturi.RepairUnwrap, aturi.RepairSchemeSlashes},
+		},
+		{
+			Input:         "\t'at:did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y'.",
+			Expected:      "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedRules: []aturi.RepairRule{aturi.RepairTrimSpace, aturi.RepairTrimPunctuation, aturi.RepairUnwrap, aturi.RepairSchemeSlashes},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, changes, err := aturi.Repair(test.Input)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("INPUT: %q", test.Input)
+			continue
+		}
+
+		if expected := test.Expected; expected != actual {
+			t.Errorf("For test #%d, the actual repaired AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("INPUT: %q", test.Input)
+			continue
+		}
+
+		if expected, actual := len(test.ExpectedRules), len(changes); expected != actual {
+			t.Errorf("For test #%d, the actual number of changes is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			t.Logf("CHANGES: %#v", changes)
+			t.Logf("INPUT: %q", test.Input)
+			continue
+		}
+
+		var before string = test.Input
+		for index, change := range changes {
+			if expected, actual := test.ExpectedRules[index], change.Rule; expected != actual {
+				t.Errorf("For test #%d and change #%d, the actual rule is not what was expected.", testNumber, index)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+				t.Logf("INPUT: %q", test.Input)
+				break
+			}
+
+			if expected, actual := before, change.Before; expected != actual {
+				t.Errorf("For test #%d and change #%d, the actual 'before' is not what was expected.", testNumber, index)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+				t.Logf("INPUT: %q", test.Input)
+				break
+			}
+
+			before = change.After
+		}
+
+		if expected, actual := test.Expected, before; expected != actual {
+			t.Errorf("For test #%d, the 'after' of the last change is not the repaired AT-URI.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("INPUT: %q", test.Input)
+			continue
+		}
+	}
+}
+
+func TestRepair_fail(t *testing.T) {
+
+	tests := []struct{
+		Input string
+		ExpectedChanges int
+	}{
+		{
+			Input: "",
+		},
+		{
+			Input: "https://example.com/",
+		},
+		{
+			Input:           "  <https://example.com/>  ",
+			ExpectedChanges: 2,
+		},
+		{
+			Input:           "at:",
+			ExpectedChanges: 1,
+		},
+		{
+			Input:           "at%3A%2F%2F%zz",
+		},
+		{
+			Input:           "at://example.com/app..bsky",
+		},
+		{
+			Input:           "at://example.com/app.bsky.feed.post/3k 3j",
+		},
+		{
+			Input:           "at://example.com/app.bsky .feed.post/3jui7kd54zh2y",
+		},
+		{
+			Input:           "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y\u00a0more",
+		},
+		{
+			// decoding would turn the escaped "%26" in the query value into a delimiter
+			Input:           "at%3A%2F%2Fexample.com%2Fapp.bsky.feed.post%3Fq%3Da%2526b",
+		},
+		{
+			Input:           "at%3A%2F%2Fexample.com%2Fapp.bsky.feed.post%2F3jui7kd54zh2y%23frag",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, changes, err := aturi.Repair(test.Input)
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("ACTUAL: %q", actual)
+			t.Logf("INPUT: %q", test.Input)
+			continue
+		}
+
+		if "" != actual {
+			t.Errorf("For test #%d, expected the repaired AT-URI to be empty but it was not.", testNumber)
+			t.Logf("ACTUAL: %q", actual)
+			t.Logf("INPUT: %q", test.Input)
+			continue
+		}
+
+		if expected, actual := test.ExpectedChanges, len(changes); expected != actual {
+			t.Errorf("For test #%d, the actual number of changes is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			t.Logf("CHANGES: %#v", changes)
+			t.Logf("INPUT: %q", test.Input)
+			continue
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package aturi
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// ResolveReference resolves a (possibly relative) AT-URI reference against a base AT-URI,
+// in the spirit of RFC 3986 section 5, but following the authority / collection / rkey hierarchy of AT-URIs
+// (rather than treating the path as directories).
+//
+// The kinds of references, and what they resolve to, are:
+//
+//	ref                                  resolves to
+//	─────────────────────────────────────────────────────────────────────────────────────────────
+//	""                                   the base, without its fragment
+//	"at://authority/…"                   the ref, as is (it is absolute)
+//	"//authority/…"                      the ref, with the "at:" scheme
+//	"/collection/rkey", "/collection"    the ref, with the authority of the base
+//	"/"                                  the authority of the base, on its own
+//	"collection/rkey"                    the ref, with the authority of the base
+//	"segment"                            if the base has a collection: the base's authority and collection, with the segment as the rkey
+//	                                     if the base has no collection: the base's authority, with the segment as the collection
+//	"?query"                             the base (without its query and fragment), with the query of the ref
+//	"#fragment"                          the base (without its fragment), with the fragment of the ref
+//
+// A path reference (anything in the table above except the query and fragment references) keeps the query and fragment of the ref (if any),
+// and never the query and fragment of the base.
+// A relative path with more than 2 segments, or with a "." or ".." segment, is an error.
+//
+// For example:
+//
+//	uri, err := aturi.ResolveReference("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post", "3jui7kd54zh2y")
+//	
+//	// uri == "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+//
+// ResolveReference returns an error if [Split] does for the base or for the result.
+func ResolveReference(base string, ref string) (string, error) {
+	authority, collection, rkey, query, _, err := Split(base)
+	if nil != err {
+		return "", err
+	}
+
+	var result string
+	switch {
+	case "" == ref:
+		result = Join(authority, collection, rkey, query, "")
+
+	case 3 <= len(ref) && strings.EqualFold(ref[:3], "at:"):
+		result = ref
+
+	case strings.HasPrefix(ref, "//"):
+		result = "at:" + ref
+
+	case strings.HasPrefix(ref, "/"):
+		// Use the component splitting of Split, with the authority of the base standing in for the (missing) authority of the ref.
+		_, refCollection, refRKey, refQuery, refFragment := splitComponents(authority + ref)
+		result = Join(authority, refCollection, refRKey, refQuery, refFragment)
+
+	case strings.HasPrefix(ref, "?"):
+		result = Join(authority, collection, rkey, "", "") + ref
+
+	case strings.HasPrefix(ref, "#"):
+		result = Join(authority, collection, rkey, query, "") + ref
+
+	default:
+		var path string = ref
+		var suffix string
+		if index := strings.IndexAny(ref, "?#"); 0 <= index {
+			path = ref[:index]
+			suffix = ref[index:]
+		}
+
+		var segments []string = strings.Split(path, "/")
+		for _, segment := range segments {
+			switch segment {
+			case ".", "..":
+				return "", newError(ErrorKindPath, ref, erorr.Errorf("aturi: reference %q may not have a %q segment", ref, segment))
+			}
+		}
+
+		switch {
+		case 2 < len(segments):
+			return "", newError(ErrorKindPath, ref, erorr.Errorf("aturi: reference %q has more segments than a collection and an rkey", ref))
+		case 2 == len(segments):
+			result = Join(authority, segments[0], segments[1], "", "") + suffix
+		case "" != collection:
+			result = Join(authority, collection, segments[0], "", "") + suffix
+		default:
+			result = Join(authority, segments[0], "", "", "") + suffix
+		}
+	}
+
+	if _, _, _, _, _, err := Split(result); nil != err {
+		return "", err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,177 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestResolveReference(t *testing.T) {
+
+	const repo       string = "at://did:plc:scewmn2pl3oz36mxme2b6czz"
+	const collection string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post"
+	const record     string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?once=1#frag"
+
+	tests := []struct{
+		Base string
+		Ref string
+		Expected string
+	}{
+		{
+			Base:     record,
+			Ref:      "",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?once=1",
+		},
+
+
+
+		{
+			Base:     record,
+			Ref:      "at://example.com/app.bsky.feed.like/3l3qo2vusxu2b",
+			Expected: "at://example.com/app.bsky.feed.like/3l3qo2vusxu2b",
+		},
+		{
+			Base:     record,
+			Ref:      "//example.com/app.bsky.feed.like/3l3qo2vusxu2b",
+			Expected: "at://example.com/app.bsky.feed.like/3l3qo2vusxu2b",
+		},
+
+
+
+		{
+			Base:     record,
+			Ref:      "/app.bsky.feed.like/3l3qo2vusxu2b",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like/3l3qo2vusxu2b",
+		},
+		{
+			Base:     record,
+			Ref:      "/app.bsky.feed.like?twice=2#other",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like?twice=2#other",
+		},
+		{
+			Base:     record,
+			Ref:      "/",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+		},
+
+
+
+		{
+			Base:     repo,
+			Ref:      "app.bsky.feed.like/3l3qo2vusxu2b",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like/3l3qo2vusxu2b",
+		},
+		{
+			Base:     record,
+			Ref:      "app.bsky.feed.like/3l3qo2vusxu2b",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.like/3l3qo2vusxu2b",
+		},
+		{
+			Base:     collection,
+			Ref:      "3l3qo2vuowo2b",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3l3qo2vuowo2b",
+		},
+		{
+			Base:     record,
+			Ref:      "3l3qo2vuowo2b#frag",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3l3qo2vuowo2b#frag",
+		},
+		{
+			Base:     repo,
+			Ref:      "app.bsky.feed.post",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+		},
+
+
+
+		{
+			Base:     record,
+			Ref:      "?twice=2",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?twice=2",
+		},
+		{
+			Base:     record,
+			Ref:      "?",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?",
+		},
+		{
+			Base:     record,
+			Ref:      "#other",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?once=1#other",
+		},
+		{
+			Base:     repo,
+			Ref:      "#other",
+			Expected: "at://did:plc:scewmn2pl3oz36mxme2b6czz#other",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, err := aturi.ResolveReference(test.Base, test.Ref)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("BASE: %q", test.Base)
+			t.Logf("REF:  %q", test.Ref)
+			continue
+		}
+
+		if expected := test.Expected; expected != actual {
+			t.Errorf("For test #%d, the actual resolved AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("BASE: %q", test.Base)
+			t.Logf("REF:  %q", test.Ref)
+			continue
+		}
+	}
+}
+
+func TestResolveReference_fail(t *testing.T) {
+
+	tests := []struct{
+		Base string
+		Ref string
+	}{
+		{
+			Base: "",
+			Ref:  "3jui7kd54zh2y",
+		},
+		{
+			Base: "https://example.com/",
+			Ref:  "3jui7kd54zh2y",
+		},
+		{
+			Base: "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+			Ref:  "3jui7kd54zh2y", // not a valid collection
+		},
+		{
+			Base: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+			Ref:  "app.bsky.feed.post/3jui7kd54zh2y/extra",
+		},
+		{
+			Base: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Ref:  "..",
+		},
+		{
+			Base: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Ref:  "/foorBar",
+		},
+		{
+			Base: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			Ref:  "//",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if actual, err := aturi.ResolveReference(test.Base, test.Ref); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("BASE: %q", test.Base)
+			t.Logf("REF:  %q", test.Ref)
+			t.Logf("ACTUAL: %q", actual)
+			continue
+		}
+	}
+}
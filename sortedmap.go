@@ -0,0 +1,208 @@
+package aturi
+
+// sortedMap is a map with string keys that keeps its keys in sorted order.
+//
+// It is an AVL tree, so get, set, and delete are O(log n).
+//
+// The zero value is an empty sortedMap ready to use.
+type sortedMap[T any] struct {
+	root   *sortedMapNode[T]
+	length int
+}
+
+type sortedMapNode[T any] struct {
+	key    string
+	value  T
+	left   *sortedMapNode[T]
+	right  *sortedMapNode[T]
+	height int
+}
+
+func (receiver *sortedMap[T]) len() int {
+	return receiver.length
+}
+
+func (receiver *sortedMap[T]) get(key string) (T, bool) {
+	var node *sortedMapNode[T] = receiver.root
+
+	for nil != node {
+		switch {
+		case key < node.key:
+			node = node.left
+		case node.key < key:
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+
+	var nada T
+	return nada, false
+}
+
+func (receiver *sortedMap[T]) set(key string, value T) {
+	var added bool
+	receiver.root = receiver.root.insert(key, value, &added)
+
+	if added {
+		receiver.length++
+	}
+}
+
+func (receiver *sortedMap[T]) delete(key string) bool {
+	var removed bool
+	receiver.root = receiver.root.remove(key, &removed)
+
+	if removed {
+		receiver.length--
+	}
+	return removed
+}
+
+// each calls fn for each key and value, in key order.
+// It stops, and returns false, if fn returns false.
+func (receiver *sortedMap[T]) each(fn func(string, T) bool) bool {
+	return receiver.root.each(fn)
+}
+
+func (receiver *sortedMapNode[T]) each(fn func(string, T) bool) bool {
+	if nil == receiver {
+		return true
+	}
+
+	return receiver.left.each(fn) && fn(receiver.key, receiver.value) && receiver.right.each(fn)
+}
+
+// eachFrom calls fn for each key (that is not less than from) and value, in key order.
+// It stops, and returns false, if fn returns false.
+//
+// It skips the subtrees that only have keys less than from, so it is O(log n) to get to the first key.
+func (receiver *sortedMap[T]) eachFrom(from string, fn func(string, T) bool) bool {
+	return receiver.root.eachFrom(from, fn)
+}
+
+func (receiver *sortedMapNode[T]) eachFrom(from string, fn func(string, T) bool) bool {
+	if nil == receiver {
+		return true
+	}
+
+	if receiver.key < from {
+		return receiver.right.eachFrom(from, fn)
+	}
+
+	return receiver.left.eachFrom(from, fn) && fn(receiver.key, receiver.value) && receiver.right.each(fn)
+}
+
+// insert returns the root of the (sub)tree after setting the value for the key.
+// It sets *added to true if the key was not already in the (sub)tree.
+func (receiver *sortedMapNode[T]) insert(key string, value T, added *bool) *sortedMapNode[T] {
+	if nil == receiver {
+		*added = true
+		return &sortedMapNode[T]{key: key, value: value, height: 1}
+	}
+
+	switch {
+	case key < receiver.key:
+		receiver.left = receiver.left.insert(key, value, added)
+	case receiver.key < key:
+		receiver.right = receiver.right.insert(key, value, added)
+	default:
+		receiver.value = value
+		return receiver
+	}
+
+	return receiver.rebalance()
+}
+
+// remove returns the root of the (sub)tree after removing the key.
+// It sets *removed to true if the key was in the (sub)tree.
+func (receiver *sortedMapNode[T]) remove(key string, removed *bool) *sortedMapNode[T] {
+	if nil == receiver {
+		return nil
+	}
+
+	switch {
+	case key < receiver.key:
+		receiver.left = receiver.left.remove(key, removed)
+	case receiver.key < key:
+		receiver.right = receiver.right.remove(key, removed)
+	default:
+		*removed = true
+
+		switch {
+		case nil == receiver.left:
+			return receiver.right
+		case nil == receiver.right:
+			return receiver.left
+		}
+
+		// Replace this node's entry with the smallest entry of the right subtree.
+		var successor *sortedMapNode[T] = receiver.right
+		for nil != successor.left {
+			successor = successor.left
+		}
+		receiver.key, receiver.value = successor.key, successor.value
+
+		var ignored bool
+		receiver.right = receiver.right.remove(successor.key, &ignored)
+	}
+
+	return receiver.rebalance()
+}
+
+func (receiver *sortedMapNode[T]) getHeight() int {
+	if nil == receiver {
+		return 0
+	}
+	return receiver.height
+}
+
+func (receiver *sortedMapNode[T]) updateHeight() {
+	receiver.height = 1 + max(receiver.left.getHeight(), receiver.right.getHeight())
+}
+
+// rebalance returns the root of the (sub)tree after rotating it (if needed) so that the heights of its two subtrees differ by at most 1.
+func (receiver *sortedMapNode[T]) rebalance() *sortedMapNode[T] {
+	receiver.updateHeight()
+
+	var balance int = receiver.left.getHeight() - receiver.right.getHeight()
+
+	switch {
+	case 1 < balance:
+		if receiver.left.left.getHeight() < receiver.left.right.getHeight() {
+			receiver.left = receiver.left.rotateLeft()
+		}
+		return receiver.rotateRight()
+	case balance < -1:
+		if receiver.right.right.getHeight() < receiver.right.left.getHeight() {
+			receiver.right = receiver.right.rotateRight()
+		}
+		return receiver.rotateLeft()
+	}
+
+	return receiver
+}
+
+func (receiver *sortedMapNode[T]) rotateLeft() *sortedMapNode[T] {
+	var root *sortedMapNode[T] = receiver.right
+
+	receiver.right = root.left
+	root.left = receiver
+
+	receiver.updateHeight()
+	root.updateHeight()
+
+	return root
+}
+
+func (receiver *sortedMapNode[T]) rotateRight() *sortedMapNode[T] {
+	var root *sortedMapNode[T] = receiver.left
+
+	receiver.left = root.right
+	root.right = receiver
+
+	receiver.updateHeight()
+	root.updateHeight()
+
+	return root
+}
@@ -2,9 +2,6 @@ package aturi
 
 import (
 	"strings"
-
-	"github.com/reiver/go-erorr"
-	"github.com/reiver/go-nsid"
 )
 
 // Split returns the 'authority', 'collection', 'rkey', 'query', and 'fragment' of at AT-URI.
@@ -23,178 +20,48 @@ import (
 //	// rkey       == "3jui7kd54zh2y"
 //	// query      == ""
 //	// fragment   == ""
+//
+// Split is a wrapper around a zero-value [Parser].
+// To use different limits, or to be more strict, use a [Parser].
 func Split(uri string) (authority string, collection string, rkey string, query string, fragment string, err error) {
-	if "" == uri {
-		return "", "", "", "", "", errEmptyURI
-	}
-
-	{
-		const max int = 8192 // == 8 kilobytes == 8 × 1 kilobyte == 8 × 1024 bytes == 8 × 2¹⁰ bytes
-
-		var length int = len(uri)
+	return split(uri)
+}
 
-		if max < length {
-			return "", "", "", "", "", erorr.Errorf("aturi: URI is %d bytes long but an AT-URI may not be more than %d bytes long", length, max)
-		}
+// splitComponents splits what comes after the "at://" of an AT-URI into its components.
+//
+// The fragment starts at the first "#", and the query at the first "?" before it.
+// So, a "/" inside a query or fragment is NOT a path separator, and a "?" inside a fragment does NOT start a query.
+// The path (i.e., the authority, collection, and rkey) is what comes before both of them.
+//
+// splitComponents does NOT validate anything.
+// It does not allocate.
+func splitComponents(str string) (authority string, collection string, rkey string, query string, fragment string) {
+	// fragment
+	if index := strings.IndexByte(str, '#'); 0 <= index {
+		fragment = str[index+1:]
+		str = str[:index]
 	}
 
-
-	var str string = uri
-	{
-		const prefix string = "at://"
-
-		var lenprefix int = len(prefix)
-		var lenuri int = len(uri)
-		if lenuri < lenprefix {
-			return "", "", "", "", "", erorr.Errorf("aturi: URI %q is not an at-uri because it does not begin with %q", uri, prefix)
-		}
-
-		var beginning string = uri[:lenprefix]
-
-		if strings.ToLower(beginning) != prefix {
-			return "", "", "", "", "", erorr.Errorf("aturi: URI %q is not an at-uri because it does not begin with %q", uri, prefix)
-		}
-
-		str = str[len(prefix):]
+	// query
+	if index := strings.IndexByte(str, '?'); 0 <= index {
+		query = str[index+1:]
+		str = str[:index]
 	}
 
 	// authority
 	{
-		var index int = strings.Index(str, "/")
+		var index int = strings.IndexByte(str, '/')
 		if index < 0 {
-			index = strings.Index(str, "?")
-			if index < 0 {
-				index = strings.Index(str, "#")
-			}
-		}
-		switch {
-		case index < 0:
 			authority = str
-			str = ""
-		default:
-			authority = str[:index]
-			str = str[index:]
-		}
-
-		if "" == authority {
-			return "", "", "", "", "", erorr.Errorf("aturi: URI %q has an empty 'authority'", uri)
-		}
-
-		{
-			const disallowed string = "@"
-
-			if strings.Contains(authority, disallowed) {
-				return "", "", "", "", "", erorr.Errorf("aturi: URI %q may not have an %q in its authority %q", uri, disallowed, authority)
-			}
+			return
 		}
-	}
 
-	switch str {
-	case "", "/", "?", "#":
-		return
+		authority = str[:index]
+		str = str[index+1:]
 	}
 
-	// collection
-	{
-		const prefix string = "/"
-
-		if strings.HasPrefix(str, prefix)  {
-			str = str[len(prefix):]
-
-			var index int = strings.Index(str, "/")
-			if index < 0 {
-				index = strings.Index(str, "?")
-				if index < 0 {
-					index = strings.Index(str, "#")
-				}
-			}
-
-			switch {
-			case index < 0:
-				collection = str
-				str = ""
-			default:
-				collection = str[:index]
-				str = str[index:]
-			}
-
-			if 0 < len(collection) {
-				if err := nsid.Validate(collection); nil != err {
-					return "", "", "", "", "", erorr.Errorf("aturi: URI %q has a collection %q that is not a valid NSID: %w", uri, collection, err)
-				}
-			}
-		}
-	}
-
-	switch str {
-	case "", "/", "?", "#":
-		return
-	}
-
-	// rkey
-	{
-		const prefix string = "/"
-
-		if strings.HasPrefix(str, prefix)  {
-			str = str[len(prefix):]
-
-			var index int = strings.Index(str, "?")
-			if index < 0 {
-				index = strings.Index(str, "#")
-			}
-
-			switch {
-			case index < 0:
-				rkey = str
-				str = ""
-			default:
-				rkey = str[:index]
-				str = str[index:]
-			}
-		}
-
-	}
-
-	switch str {
-	case "", "?", "#":
-		return
-	}
-
-	// query
-	{
-		const prefix string = "?"
-
-		if strings.HasPrefix(str, prefix)  {
-			str = str[len(prefix):]
-
-			var index int = strings.Index(str, "#")
-
-			switch {
-			case index < 0:
-				query = str
-				str = ""
-			default:
-				query = str[:index]
-				str = str[index:]
-			}
-		}
-	}
-
-	switch str {
-	case "", "#":
-		return
-	}
-
-	// fragment
-	{
-		const prefix string = "#"
-
-		if strings.HasPrefix(str, prefix)  {
-			str = str[len(prefix):]
-
-			fragment = str
-		}
-	}
+	// collection and rkey
+	collection, rkey, _ = cutPath(str)
 
 	return
 }
@@ -0,0 +1,81 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+func BenchmarkSplit(b *testing.B) {
+
+	benchmarks := []struct{
+		Name string
+		URI string
+	}{
+		{
+			Name: "authority",
+			URI:  "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+		},
+		{
+			Name: "collection",
+			URI:  "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar",
+		},
+		{
+			Name: "rkey",
+			URI:  "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y",
+		},
+		{
+			Name: "query-fragment",
+			URI:  "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
+		},
+		{
+			Name: "long-rkey",
+			URI:  "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/" + strings.Repeat("0123456789ABCDEFGHIJKLMNOPQRSTUV", 250),
+		},
+	}
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _, _, _, _, err := aturi.Split(benchmark.URI)
+				if nil != err {
+					b.Fatalf("did not expect an error but actually got one: (%T) %s", err, err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParser_Split(b *testing.B) {
+
+	const uri string = "at://did:plc:scewmn2pl3oz36mxme2b6czz"
+
+	benchmarks := []struct{
+		Name string
+		Parser aturi.Parser
+	}{
+		{
+			Name:   "zero",
+			Parser: aturi.Parser{},
+		},
+		{
+			Name:   "options",
+			Parser: aturi.Parser{MaxLength: 2048, DisallowQuery: true, DisallowFragment: true},
+		},
+	}
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _, _, _, _, err := benchmark.Parser.Split(uri)
+				if nil != err {
+					b.Fatalf("did not expect an error but actually got one: (%T) %s", err, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"net/url"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+func FuzzSplit(f *testing.F) {
+
+	for _, test := range splitTests {
+		f.Add(test.URI)
+	}
+	for _, test := range splitFailTests {
+		f.Add(test.URI)
+	}
+
+	f.Fuzz(func(t *testing.T, uri string) {
+
+		authority, collection, rkey, query, fragment, err := aturi.Split(uri)
+		if nil != err {
+			return
+		}
+
+		// round-trip
+		{
+			var reassembled string = aturi.Join(authority, collection, rkey, query, fragment)
+
+			actualAuthority, actualCollection, actualRKey, actualQuery, actualFragment, err := aturi.Split(reassembled)
+			if nil != err {
+				t.Errorf("Did not expect an error when splitting the reassembled URI but actually got one.")
+				t.Logf("ERROR: (%T) %s", err, err)
+				t.Logf("URI:         %q", uri)
+				t.Logf("REASSEMBLED: %q", reassembled)
+				return
+			}
+
+			if authority  != actualAuthority ||
+			   collection != actualCollection ||
+			   rkey       != actualRKey ||
+			   query      != actualQuery ||
+			   fragment   != actualFragment {
+				t.Errorf("The reassembled URI did not split into the same components.")
+				t.Logf("URI:         %q", uri)
+				t.Logf("REASSEMBLED: %q", reassembled)
+				t.Logf("EXPECTED: authority=%q collection=%q rkey=%q query=%q fragment=%q", authority, collection, rkey, query, fragment)
+				t.Logf("ACTUAL:   authority=%q collection=%q rkey=%q query=%q fragment=%q", actualAuthority, actualCollection, actualRKey, actualQuery, actualFragment)
+				return
+			}
+		}
+
+		// differential (against net/url)
+		//
+		// url.Parse rejects a DID authority (such as "did:plc:scewmn2pl3oz36mxme2b6czz"), because it takes what comes after a ":" to be a port.
+		// So, the authority is swapped for a placeholder host, and url.Parse has to agree on where the authority ends.
+		{
+			const placeholder string = "authority.invalid"
+
+			var rest string = uri[len("at://")+len(authority):]
+			if authority != uri[len("at://"):len(uri)-len(rest)] {
+				t.Errorf("The authority aturi.Split returned is not what comes right after the \"at://\".")
+				t.Logf("URI: %q", uri)
+				t.Logf("aturi.Split: authority=%q", authority)
+				return
+			}
+
+			u, err := url.Parse("at://" + placeholder + rest)
+			if nil != err {
+				return
+			}
+
+			if placeholder != u.Host {
+				t.Errorf("aturi.Split and url.Parse do not agree on where the authority ends.")
+				t.Logf("URI: %q", uri)
+				t.Logf("aturi.Split: authority=%q", authority)
+				t.Logf("url.Parse:   host=%q (with the authority swapped for %q)", u.Host, placeholder)
+				return
+			}
+
+			var expectedPath string = u.Path
+
+			var actualPath string
+			{
+				var path string
+				if "" != collection || "" != rkey {
+					path = "/" + collection
+				}
+				if "" != rkey {
+					path += "/" + rkey
+				}
+
+				actualPath, err = url.PathUnescape(path)
+				if nil != err {
+					return
+				}
+			}
+
+			// aturi.Split does not report trailing slashes after the collection.
+			if "" == rkey {
+				expectedPath = strings.TrimRight(expectedPath, "/")
+				actualPath   = strings.TrimRight(actualPath, "/")
+			}
+
+			unescapedFragment, err := url.PathUnescape(fragment)
+			if nil != err {
+				return
+			}
+
+			if expectedPath != actualPath ||
+			   u.RawQuery   != query ||
+			   u.Fragment   != unescapedFragment {
+				t.Errorf("aturi.Split and url.Parse do not agree.")
+				t.Logf("URI: %q", uri)
+				t.Logf("aturi.Split: authority=%q collection=%q rkey=%q query=%q fragment=%q", authority, collection, rkey, query, fragment)
+				t.Logf("url.Parse:   host=%q path=%q rawquery=%q fragment=%q", u.Host, u.Path, u.RawQuery, u.Fragment)
+				return
+			}
+		}
+	})
+}
@@ -6,754 +6,783 @@ import (
 	"strings"
 
 	"github.com/reiver/go-aturi"
+	"github.com/reiver/go-nsid"
 )
 
-func TestSplit(t *testing.T) {
-
-	tests := []struct{
-		URI string
-		ExpectedAuthority string
-		ExpectedCollection string
-		ExpectedRKey string
-		ExpectedQuery string
-		ExpectedFragment string
-	}{
-		{
-			URI:          "at://foo.com/com.example.foo/123",
-			ExpectedAuthority: "foo.com",
-			ExpectedCollection:        "com.example.foo",
-			ExpectedRKey:                              "123",
-		},
-
-
-
-		{
-			URI:          "AT://localhost",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "AT://example.com",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "AT://example.com.",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "AT://apple.banana.cherry",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "AT://xn--ugbaf6g.example",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "AT://did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "At://localhost",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "At://example.com",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "At://example.com.",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "At://apple.banana.cherry",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "At://xn--ugbaf6g.example",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "At://did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "aT://localhost",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "aT://example.com",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "aT://example.com.",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "aT://apple.banana.cherry",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "aT://xn--ugbaf6g.example",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "aT://did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "at://example.com",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "at://example.com.",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "at://apple.banana.cherry",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost/",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "at://example.com/",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "at://example.com./",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "at://apple.banana.cherry/",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost?",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "at://example.com?",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "at://example.com.?",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "at://apple.banana.cherry?",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example?",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz?",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost#",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "at://example.com#",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "at://example.com.#",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "at://apple.banana.cherry#",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example#",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz#",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost/?",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "at://example.com/?",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "at://example.com./?",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "at://apple.banana.cherry/?",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/?",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/?",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost/#",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "at://example.com/#",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "at://example.com./#",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "at://apple.banana.cherry/#",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/#",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/#",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost?#",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "at://example.com?#",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "at://example.com.?#",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "at://apple.banana.cherry?#",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example?#",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz?#",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost/?#",
-			ExpectedAuthority: "localhost",
-		},
-		{
-			URI:          "at://example.com/?#",
-			ExpectedAuthority: "example.com",
-		},
-		{
-			URI:          "at://example.com./?#",
-			ExpectedAuthority: "example.com.",
-		},
-		{
-			URI:          "at://apple.banana.cherry/?#",
-			ExpectedAuthority: "apple.banana.cherry",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/?#",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/?#",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar/",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar/",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar/",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar/",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar/",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar?",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar?",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar?",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar?",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar?",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar?",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar#",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar#",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar#",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar#",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar#",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar#",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar/?#",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar/?#",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar/?#",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar/?#",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar/?#",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/?#",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-			ExpectedRKey:                                    "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-			ExpectedRKey:                                      "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-			ExpectedRKey:                                       "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-			ExpectedRKey:                                                           "3jui7kd54zh2y",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y?",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-			ExpectedRKey:                                    "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y?",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-			ExpectedRKey:                                      "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y?",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-			ExpectedRKey:                                       "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y?",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y?",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-			ExpectedRKey:                                                           "3jui7kd54zh2y",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y#",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-			ExpectedRKey:                                    "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y#",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-			ExpectedRKey:                                      "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y#",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-			ExpectedRKey:                                       "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y#",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y#",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y#",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-			ExpectedRKey:                                                           "3jui7kd54zh2y",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-			ExpectedRKey:                                    "3jui7kd54zh2y",
-			ExpectedQuery:                                                 "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-			ExpectedRKey:                                      "3jui7kd54zh2y",
-			ExpectedQuery:                                                   "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-			ExpectedRKey:                                       "3jui7kd54zh2y",
-			ExpectedQuery:                                                    "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-			ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-			ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-			ExpectedRKey:                                                           "3jui7kd54zh2y",
-			ExpectedQuery:                                                                        "once=1&twice=2&thrice=3&fource=4",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-			ExpectedRKey:                                    "3jui7kd54zh2y",
-			ExpectedQuery:                                                 "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-			ExpectedRKey:                                      "3jui7kd54zh2y",
-			ExpectedQuery:                                                   "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-			ExpectedRKey:                                       "3jui7kd54zh2y",
-			ExpectedQuery:                                                    "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-			ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-			ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-			ExpectedRKey:                                                           "3jui7kd54zh2y",
-			ExpectedQuery:                                                                        "once=1&twice=2&thrice=3&fource=4",
-		},
-
-
-
-		{
-			URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
-			ExpectedAuthority: "localhost",
-			ExpectedCollection:          "com.example.foorBar",
-			ExpectedRKey:                                    "3jui7kd54zh2y",
-			ExpectedQuery:                                                 "once=1&twice=2&thrice=3&fource=4",
-			ExpectedFragment:                                                                               "path(/apple/banana/cherry)",
-		},
-		{
-			URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
-			ExpectedAuthority: "example.com",
-			ExpectedCollection:            "com.example.foorBar",
-			ExpectedRKey:                                      "3jui7kd54zh2y",
-			ExpectedQuery:                                                   "once=1&twice=2&thrice=3&fource=4",
-			ExpectedFragment:                                                                                 "path(/apple/banana/cherry)",
-		},
-		{
-			URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
-			ExpectedAuthority: "example.com.",
-			ExpectedCollection:             "com.example.foorBar",
-			ExpectedRKey:                                       "3jui7kd54zh2y",
-			ExpectedQuery:                                                    "once=1&twice=2&thrice=3&fource=4",
-			ExpectedFragment:                                                                                  "path(/apple/banana/cherry)",
-		},
-		{
-			URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
-			ExpectedAuthority: "apple.banana.cherry",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-			ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
-			ExpectedFragment:                                                                                         "path(/apple/banana/cherry)",
-		},
-		{
-			URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
-			ExpectedAuthority: "xn--ugbaf6g.example",
-			ExpectedCollection:                    "com.example.foorBar",
-			ExpectedRKey:                                              "3jui7kd54zh2y",
-			ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
-			ExpectedFragment:                                                                                         "path(/apple/banana/cherry)",
-		},
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-			ExpectedRKey:                                                           "3jui7kd54zh2y",
-			ExpectedQuery:                                                                        "once=1&twice=2&thrice=3&fource=4",
-			ExpectedFragment:                                                                                                      "path(/apple/banana/cherry)",
-		},
+var splitTests = []struct{
+	URI string
+	ExpectedAuthority string
+	ExpectedCollection string
+	ExpectedRKey string
+	ExpectedQuery string
+	ExpectedFragment string
+}{
+	{
+		URI:          "at://foo.com/com.example.foo/123",
+		ExpectedAuthority: "foo.com",
+		ExpectedCollection:        "com.example.foo",
+		ExpectedRKey:                              "123",
+	},
+
+
+
+	{
+		URI:          "AT://localhost",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "AT://example.com",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "AT://example.com.",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "AT://apple.banana.cherry",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "AT://xn--ugbaf6g.example",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "AT://did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "At://localhost",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "At://example.com",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "At://example.com.",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "At://apple.banana.cherry",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "At://xn--ugbaf6g.example",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "At://did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "aT://localhost",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "aT://example.com",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "aT://example.com.",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "aT://apple.banana.cherry",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "aT://xn--ugbaf6g.example",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "aT://did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "at://example.com",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "at://example.com.",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "at://apple.banana.cherry",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost/",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "at://example.com/",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "at://example.com./",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "at://apple.banana.cherry/",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost?",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "at://example.com?",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "at://example.com.?",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "at://apple.banana.cherry?",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example?",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz?",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost#",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "at://example.com#",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "at://example.com.#",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "at://apple.banana.cherry#",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example#",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz#",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost/?",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "at://example.com/?",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "at://example.com./?",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "at://apple.banana.cherry/?",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/?",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/?",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost/#",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "at://example.com/#",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "at://example.com./#",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "at://apple.banana.cherry/#",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/#",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/#",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost?#",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "at://example.com?#",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "at://example.com.?#",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "at://apple.banana.cherry?#",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example?#",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz?#",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost/?#",
+		ExpectedAuthority: "localhost",
+	},
+	{
+		URI:          "at://example.com/?#",
+		ExpectedAuthority: "example.com",
+	},
+	{
+		URI:          "at://example.com./?#",
+		ExpectedAuthority: "example.com.",
+	},
+	{
+		URI:          "at://apple.banana.cherry/?#",
+		ExpectedAuthority: "apple.banana.cherry",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/?#",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/?#",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar/",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar/",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar/",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar/",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar?",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar?",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar?",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar?",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar?",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar?",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar#",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar#",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar#",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar#",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar#",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar#",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar/?#",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/?#",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar/?#",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar/?#",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar/?#",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/?#",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+		ExpectedRKey:                                    "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+		ExpectedRKey:                                      "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+		ExpectedRKey:                                       "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+		ExpectedRKey:                                                           "3jui7kd54zh2y",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y?",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+		ExpectedRKey:                                    "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y?",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+		ExpectedRKey:                                      "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y?",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+		ExpectedRKey:                                       "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y?",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y?",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+		ExpectedRKey:                                                           "3jui7kd54zh2y",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y#",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+		ExpectedRKey:                                    "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y#",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+		ExpectedRKey:                                      "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y#",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+		ExpectedRKey:                                       "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y#",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y#",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y#",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+		ExpectedRKey:                                                           "3jui7kd54zh2y",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+		ExpectedRKey:                                    "3jui7kd54zh2y",
+		ExpectedQuery:                                                 "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+		ExpectedRKey:                                      "3jui7kd54zh2y",
+		ExpectedQuery:                                                   "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+		ExpectedRKey:                                       "3jui7kd54zh2y",
+		ExpectedQuery:                                                    "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+		ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+		ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+		ExpectedRKey:                                                           "3jui7kd54zh2y",
+		ExpectedQuery:                                                                        "once=1&twice=2&thrice=3&fource=4",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+		ExpectedRKey:                                    "3jui7kd54zh2y",
+		ExpectedQuery:                                                 "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+		ExpectedRKey:                                      "3jui7kd54zh2y",
+		ExpectedQuery:                                                   "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+		ExpectedRKey:                                       "3jui7kd54zh2y",
+		ExpectedQuery:                                                    "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+		ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+		ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+		ExpectedRKey:                                                           "3jui7kd54zh2y",
+		ExpectedQuery:                                                                        "once=1&twice=2&thrice=3&fource=4",
+	},
+
+
+
+	{
+		URI:          "at://localhost/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
+		ExpectedAuthority: "localhost",
+		ExpectedCollection:          "com.example.foorBar",
+		ExpectedRKey:                                    "3jui7kd54zh2y",
+		ExpectedQuery:                                                 "once=1&twice=2&thrice=3&fource=4",
+		ExpectedFragment:                                                                               "path(/apple/banana/cherry)",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+		ExpectedRKey:                                      "3jui7kd54zh2y",
+		ExpectedQuery:                                                   "once=1&twice=2&thrice=3&fource=4",
+		ExpectedFragment:                                                                                 "path(/apple/banana/cherry)",
+	},
+	{
+		URI:          "at://example.com./com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
+		ExpectedAuthority: "example.com.",
+		ExpectedCollection:             "com.example.foorBar",
+		ExpectedRKey:                                       "3jui7kd54zh2y",
+		ExpectedQuery:                                                    "once=1&twice=2&thrice=3&fource=4",
+		ExpectedFragment:                                                                                  "path(/apple/banana/cherry)",
+	},
+	{
+		URI:          "at://apple.banana.cherry/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
+		ExpectedAuthority: "apple.banana.cherry",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+		ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
+		ExpectedFragment:                                                                                         "path(/apple/banana/cherry)",
+	},
+	{
+		URI:          "at://xn--ugbaf6g.example/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
+		ExpectedAuthority: "xn--ugbaf6g.example",
+		ExpectedCollection:                    "com.example.foorBar",
+		ExpectedRKey:                                              "3jui7kd54zh2y",
+		ExpectedQuery:                                                           "once=1&twice=2&thrice=3&fource=4",
+		ExpectedFragment:                                                                                         "path(/apple/banana/cherry)",
+	},
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/3jui7kd54zh2y?once=1&twice=2&thrice=3&fource=4#path(/apple/banana/cherry)",
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+		ExpectedRKey:                                                           "3jui7kd54zh2y",
+		ExpectedQuery:                                                                        "once=1&twice=2&thrice=3&fource=4",
+		ExpectedFragment:                                                                                                      "path(/apple/banana/cherry)",
+	},
+
+
+
+	{
+		URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/" + strings.Repeat("0123456789ABCDEFGHIJKLMNOPQRSTUV", 256)[len("at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/"):],
+		ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
+		ExpectedCollection:                                 "com.example.foorBar",
+		ExpectedRKey:                                                           "QRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV",
+	},
+
+
+
+	{
+		URI:          "at://example.com?once=1/twice=2",
+		ExpectedAuthority: "example.com",
+		ExpectedQuery:                 "once=1/twice=2",
+	},
+	{
+		URI:          "at://example.com#path(/apple/banana/cherry)",
+		ExpectedAuthority: "example.com",
+		ExpectedFragment:              "path(/apple/banana/cherry)",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar?once=1/twice=2",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+		ExpectedQuery:                                     "once=1/twice=2",
+	},
+	{
+		URI:          "at://example.com/com.example.foorBar/3jui7kd54zh2y#path(?once=1)",
+		ExpectedAuthority: "example.com",
+		ExpectedCollection:            "com.example.foorBar",
+		ExpectedRKey:                                      "3jui7kd54zh2y",
+		ExpectedFragment:                                                "path(?once=1)",
+	},
+}
 
+func TestSplit(t *testing.T) {
 
-
-		{
-			URI:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/" + strings.Repeat("0123456789ABCDEFGHIJKLMNOPQRSTUV", 256)[len("at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/"):],
-			ExpectedAuthority: "did:plc:scewmn2pl3oz36mxme2b6czz",
-			ExpectedCollection:                                 "com.example.foorBar",
-			ExpectedRKey:                                                           "QRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV0123456789ABCDEFGHIJKLMNOPQRSTUV",
-		},
-	}
+	tests := splitTests
 
 	for testNumber, test := range tests {
 
@@ -834,99 +863,109 @@ func TestSplit(t *testing.T) {
 	}
 }
 
-func TestSplit_fail(t *testing.T) {
-
-	tests := []struct{
-		URI string
-		ExpectedError string
-	}{
-		{
-			URI: "at://foo.com/example/123",
-			ExpectedError: `aturi: URI "at://foo.com/example/123" has a collection "example" that is not a valid NSID: nsid: nsid ("example") should have at least 3 segments but actually has 1`,
-		},
-		{
-			URI: "at://user:pass@foo.com",
-			ExpectedError: `aturi: URI "at://user:pass@foo.com" may not have an "@" in its authority "user:pass@foo.com"`,
-		},
-
-
-
-		{
-			URI: "",
-			ExpectedError: `aturi: empty URI`,
-		},
-
-
-
-		{
-			URI: "apple",
-			ExpectedError: `aturi: URI "apple" is not an at-uri because it does not begin with "at://"`,
-		},
-		{
-			URI: "banana",
-			ExpectedError: `aturi: URI "banana" is not an at-uri because it does not begin with "at://"`,
-		},
-		{
-			URI: "cherry",
-			ExpectedError: `aturi: URI "cherry" is not an at-uri because it does not begin with "at://"`,
-		},
-
-
-
-		{
-			URI: "at",
-			ExpectedError: `aturi: URI "at" is not an at-uri because it does not begin with "at://"`,
-		},
-		{
-			URI: "at:",
-			ExpectedError: `aturi: URI "at:" is not an at-uri because it does not begin with "at://"`,
-		},
-
-
-
-		{
-			URI: "at://",
-			ExpectedError: `aturi: URI "at://" has an empty 'authority'`,
-		},
-		{
-			URI: "at:///",
-			ExpectedError: `aturi: URI "at:///" has an empty 'authority'`,
-		},
-		{
-			URI: "at://?",
-			ExpectedError: `aturi: URI "at://?" has an empty 'authority'`,
-		},
-		{
-			URI: "at://#",
-			ExpectedError: `aturi: URI "at://#" has an empty 'authority'`,
-		},
-		{
-			URI: "at://?#",
-			ExpectedError: `aturi: URI "at://?#" has an empty 'authority'`,
-		},
-
-
-
-		{
-			URI: "at://@",
-			ExpectedError: `aturi: URI "at://@" may not have an "@" in its authority "@"`,
-		},
-		{
-			URI: "at://@example",
-			ExpectedError: `aturi: URI "at://@example" may not have an "@" in its authority "@example"`,
-		},
-		{
-			URI: "at://@example.com",
-			ExpectedError: `aturi: URI "at://@example.com" may not have an "@" in its authority "@example.com"`,
-		},
+var splitFailTests = []struct{
+	URI string
+	ExpectedError string
+}{
+	{
+		URI: "at://foo.com/example/123",
+		ExpectedError: `aturi: URI "at://foo.com/example/123" has a collection "example" that is not a valid NSID: nsid: nsid ("example") should have at least 3 segments but actually has 1`,
+	},
+	{
+		URI: "at://user:pass@foo.com",
+		ExpectedError: `aturi: URI "at://user:pass@foo.com" may not have an "@" in its authority "user:pass@foo.com"`,
+	},
+
+
+
+	{
+		URI: "",
+		ExpectedError: `aturi: empty URI`,
+	},
+
+
+
+	{
+		URI: "apple",
+		ExpectedError: `aturi: URI "apple" is not an at-uri because it does not begin with "at://"`,
+	},
+	{
+		URI: "banana",
+		ExpectedError: `aturi: URI "banana" is not an at-uri because it does not begin with "at://"`,
+	},
+	{
+		URI: "cherry",
+		ExpectedError: `aturi: URI "cherry" is not an at-uri because it does not begin with "at://"`,
+	},
+
+
+
+	{
+		URI: "at",
+		ExpectedError: `aturi: URI "at" is not an at-uri because it does not begin with "at://"`,
+	},
+	{
+		URI: "at:",
+		ExpectedError: `aturi: URI "at:" is not an at-uri because it does not begin with "at://"`,
+	},
+
+
+
+	{
+		URI: "at://",
+		ExpectedError: `aturi: URI "at://" has an empty 'authority'`,
+	},
+	{
+		URI: "at:///",
+		ExpectedError: `aturi: URI "at:///" has an empty 'authority'`,
+	},
+	{
+		URI: "at://?",
+		ExpectedError: `aturi: URI "at://?" has an empty 'authority'`,
+	},
+	{
+		URI: "at://#",
+		ExpectedError: `aturi: URI "at://#" has an empty 'authority'`,
+	},
+	{
+		URI: "at://?#",
+		ExpectedError: `aturi: URI "at://?#" has an empty 'authority'`,
+	},
+	{
+		URI: "at://#/",
+		ExpectedError: `aturi: URI "at://#/" has an empty 'authority'`,
+	},
+	{
+		URI: "at://?q=a/b",
+		ExpectedError: `aturi: URI "at://?q=a/b" has an empty 'authority'`,
+	},
+
+
+
+	{
+		URI: "at://@",
+		ExpectedError: `aturi: URI "at://@" may not have an "@" in its authority "@"`,
+	},
+	{
+		URI: "at://@example",
+		ExpectedError: `aturi: URI "at://@example" may not have an "@" in its authority "@example"`,
+	},
+	{
+		URI: "at://@example.com",
+		ExpectedError: `aturi: URI "at://@example.com" may not have an "@" in its authority "@example.com"`,
+	},
+
+
+
+	{
+		URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/" + strings.Repeat("0123456789ABCDEFGHIJKLMNOPQRSTUV", 256)[len("at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/")-1:],
+		ExpectedError: `aturi: URI is 8193 bytes long but an AT-URI may not be more than 8192 bytes long`,
+	},
+}
 
+func TestSplit_fail(t *testing.T) {
 
-
-		{
-			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/" + strings.Repeat("0123456789ABCDEFGHIJKLMNOPQRSTUV", 256)[len("at://did:plc:scewmn2pl3oz36mxme2b6czz/com.example.foorBar/")-1:],
-			ExpectedError: `aturi: URI is 8193 bytes long but an AT-URI may not be more than 8192 bytes long`,
-		},
-	}
+	tests := splitFailTests
 
 	for testNumber, test := range tests {
 
@@ -954,3 +993,81 @@ func TestSplit_fail(t *testing.T) {
 		}
 	}
 }
+
+func TestSplit_allocs(t *testing.T) {
+
+	for testNumber, test := range splitTests {
+
+		allocs := testing.AllocsPerRun(10, func() {
+			aturi.Split(test.URI)
+		})
+
+		if 0 != allocs {
+			t.Errorf("For test #%d, expected splitting to not allocate but it actually did %v allocations.", testNumber, allocs)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+	}
+}
+
+// TestSplit_collection checks that Split accepts exactly the collections that nsid.Validate does,
+// since Split only calls nsid.Validate for a collection that its own (non-allocating) check rejects.
+func TestSplit_collection(t *testing.T) {
+
+	var collections []string
+	collections = append(collections, loadInteropVectors(t, "nsid_syntax_valid.txt")...)
+	collections = append(collections, loadInteropVectors(t, "nsid_syntax_invalid.txt")...)
+	collections = append(collections,
+		"com.example.fooBar",
+		"Com.example.fooBar",
+		"com.Example.fooBar",
+		"com.example.foo-bar",
+		"com.example.foo1",
+		"com.exa-mple.foo",
+		"com.-example.foo",
+		"com.example-.foo",
+		"com.0example.foo",
+		"c0m.example.foo",
+		"0com.example.foo",
+		"com..foo",
+		"com.example.",
+		"com.example",
+		".com.example.foo",
+		"com.example.foo.",
+		"com.example.foo_bar",
+		"com."+strings.Repeat("o", 63)+".foo",
+		"com."+strings.Repeat("o", 64)+".foo",
+		"com.example."+strings.Repeat("o", 63),
+		"com.example."+strings.Repeat("o", 64),
+		strings.Repeat("abcdefghi.", 25)+"abc.foo",
+		strings.Repeat("abcdefghi.", 25)+"abcd.foo",
+		strings.Repeat("a.", 158)+"foo",
+		"com.exämple.foo",
+	)
+
+	for _, collection := range collections {
+
+		if strings.ContainsAny(collection, "/?#") {
+			continue
+		}
+
+		var uri string = "at://example.com/" + collection
+
+		_, actualCollection, _, _, _, err := aturi.Split(uri)
+		var expected error = nsid.Validate(collection)
+
+		if (nil == expected) != (nil == err) {
+			t.Errorf("aturi.Split and nsid.Validate do not agree about the collection %q.", collection)
+			t.Logf("nsid.Validate: %v", expected)
+			t.Logf("aturi.Split:   %v", err)
+			continue
+		}
+
+		if nil == err && collection != actualCollection {
+			t.Errorf("The actual collection is not what was expected.")
+			t.Logf("EXPECTED: %q", collection)
+			t.Logf("ACTUAL:   %q", actualCollection)
+			continue
+		}
+	}
+}
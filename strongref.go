@@ -0,0 +1,155 @@
+package aturi
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/reiver/go-erorr"
+)
+
+// StrongRef is a reference to a specific version of a record: an AT-URI along with the CID of the record.
+//
+// It is what the "com.atproto.repo.strongRef" Lexicon type (`{"uri": "…", "cid": "…"}`) holds,
+// and shows up in likes, reposts, replies, quote embeds, etc.
+//
+// For example:
+//
+//	ref, err := aturi.NewStrongRef(
+//		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+//		"bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a",
+//	)
+//
+// StrongRef is marshaled to (and unmarshaled from) JSON as a map with a "uri" and a "cid".
+// Marshaling and unmarshaling both return an error if the StrongRef is not valid (see [StrongRef.Validate]).
+// That includes the zero StrongRef, so that a record with an unset (but required) strong-ref is never published.
+//
+// Optional strong-refs are left out in atproto (rather than being null).
+// So, for an optional field, use a *StrongRef with "omitempty":
+//
+//	type Post struct {
+//		Text  string           `json:"text"`
+//		Reply *aturi.StrongRef `json:"reply,omitempty"`
+//	}
+//
+// To marshal a StrongRef to (and unmarshal it from) DAG-CBOR, use [github.com/reiver/go-aturi/cbor.StrongRef].
+type StrongRef struct {
+	URI string
+	CID string
+}
+
+var (
+	_ json.Marshaler   = StrongRef{}
+	_ json.Unmarshaler = &StrongRef{}
+)
+
+// strongRefFields is what a StrongRef looks like when it is marshaled.
+type strongRefFields struct {
+	URI string `json:"uri"`
+	CID string `json:"cid"`
+}
+
+// NewStrongRef returns a StrongRef, or an error if the StrongRef would not be valid (see [StrongRef.Validate]).
+func NewStrongRef(uri string, cid string) (StrongRef, error) {
+	var ref = StrongRef{
+		URI: uri,
+		CID: cid,
+	}
+
+	if err := ref.Validate(); nil != err {
+		return StrongRef{}, err
+	}
+
+	return ref, nil
+}
+
+// Validate returns an error if the StrongRef is not valid.
+//
+// The URI must pass [ValidateStrict] and must point to a record (so it must have both a collection and an rkey).
+// An invalid URI is reported as an [*Error].
+//
+// The CID must be a CIDv1 in its multibase base32 string form (which always begins with a 'b').
+func (receiver StrongRef) Validate() error {
+	if err := ValidateStrict(receiver.URI); nil != err {
+		return err
+	}
+
+	{
+		_, collection, rkey, _, _, _ := Split(receiver.URI)
+
+		if "" == collection || "" == rkey {
+			return newError(ErrorKindPath, receiver.URI, erorr.Errorf("aturi: strong-ref URI %q does not point to a record (it needs both a collection and an rkey)", receiver.URI))
+		}
+	}
+
+	if err := validateCID(receiver.CID); nil != err {
+		return erorr.Errorf("aturi: strong-ref to %q has an invalid CID: %w", receiver.URI, err)
+	}
+
+	return nil
+}
+
+// Equal returns whether two StrongRefs refer to the same version of the same record.
+//
+// The URIs are compared after [Normalize] (so a handle authority is compared case-insensitively).
+// The CIDs are compared exactly.
+func (receiver StrongRef) Equal(other StrongRef) bool {
+	if receiver.CID != other.CID {
+		return false
+	}
+
+	if receiver.URI == other.URI {
+		return true
+	}
+
+	uri, err := Normalize(receiver.URI)
+	if nil != err {
+		return false
+	}
+	otherURI, err := Normalize(other.URI)
+	if nil != err {
+		return false
+	}
+
+	return uri == otherURI
+}
+
+// WithoutCID returns just the AT-URI, which refers to the record no matter what version of it there is.
+func (receiver StrongRef) WithoutCID() string {
+	return receiver.URI
+}
+
+// MarshalJSON makes StrongRef fit the json.Marshaler interface.
+func (receiver StrongRef) MarshalJSON() ([]byte, error) {
+	if err := receiver.Validate(); nil != err {
+		return nil, err
+	}
+
+	return json.Marshal(strongRefFields(receiver))
+}
+
+// UnmarshalJSON makes StrongRef fit the json.Unmarshaler interface.
+//
+// Any other fields (such as "$type") are ignored.
+// A JSON null is a no-op (the way it is for encoding/json itself).
+func (receiver *StrongRef) UnmarshalJSON(data []byte) error {
+	if nil == receiver {
+		return errNilReceiver
+	}
+
+	if "null" == string(bytes.TrimSpace(data)) {
+		return nil
+	}
+
+	var fields strongRefFields
+	if err := json.Unmarshal(data, &fields); nil != err {
+		return erorr.Errorf("aturi: problem unmarshaling strong-ref from JSON: %w", err)
+	}
+
+	var ref StrongRef = StrongRef(fields)
+	if err := ref.Validate(); nil != err {
+		return err
+	}
+
+	*receiver = ref
+	return nil
+}
@@ -0,0 +1,265 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"encoding/json"
+	"errors"
+
+	"github.com/reiver/go-aturi"
+)
+
+const (
+	testStrongRefURI string = "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y"
+	testStrongRefCID string = "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a"
+)
+
+func TestNewStrongRef(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		CID string
+	}{
+		{
+			URI: testStrongRefURI,
+			CID: testStrongRefCID,
+		},
+		{
+			URI: "at://example.com/app.bsky.feed.like/self",
+			CID: "bafkreibme22gw2h7y2h7tg2fhqotaqjucnbc24deqo72b6mkl2egezxhvy",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		ref, err := aturi.NewStrongRef(test.URI, test.CID)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			t.Logf("CID: %q", test.CID)
+			continue
+		}
+
+		if expected, actual := test.URI, ref.WithoutCID(); expected != actual {
+			t.Errorf("For test #%d, the actual URI from WithoutCID() is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestNewStrongRef_fail(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		CID string
+		ExpectedKind aturi.ErrorKind
+	}{
+		{
+			URI: "",
+			CID: testStrongRefCID,
+			ExpectedKind: aturi.ErrorKindEmpty,
+		},
+		{
+			URI: "AT://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			CID: testStrongRefCID,
+			ExpectedKind: aturi.ErrorKindScheme,
+		},
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+			CID: testStrongRefCID,
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+		{
+			URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post",
+			CID: testStrongRefCID,
+			ExpectedKind: aturi.ErrorKindPath,
+		},
+
+
+
+		{
+			URI: testStrongRefURI,
+			CID: "",
+		},
+		{
+			URI: testStrongRefURI,
+			CID: "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", // CIDv0
+		},
+		{
+			URI: testStrongRefURI,
+			CID: "BAFYREIDFAYVFUWQA7QLNOPDJIQRXZS6BLMOEU4RUJCJTNCI5BELUDIRZ2A", // upper-case multibase
+		},
+		{
+			URI: testStrongRefURI,
+			CID: "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2", // truncated
+		},
+		{
+			URI: testStrongRefURI,
+			CID: "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a1", // '1' is not base32
+		},
+		{
+			URI: testStrongRefURI,
+			CID: "bajyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a", // version 2
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		_, err := aturi.NewStrongRef(test.URI, test.CID)
+		if nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URI: %q", test.URI)
+			t.Logf("CID: %q", test.CID)
+			continue
+		}
+
+		if "" == test.ExpectedKind {
+			continue
+		}
+
+		var aturiError *aturi.Error
+		if !errors.As(err, &aturiError) {
+			t.Errorf("For test #%d, expected the error to be an %T but it actually is not.", testNumber, aturiError)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := test.ExpectedKind, aturiError.Kind; expected != actual {
+			t.Errorf("For test #%d, the actual error kind is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestStrongRef_Equal(t *testing.T) {
+
+	tests := []struct{
+		Ref   aturi.StrongRef
+		Other aturi.StrongRef
+		Expected bool
+	}{
+		{
+			Ref:   aturi.StrongRef{URI: testStrongRefURI, CID: testStrongRefCID},
+			Other: aturi.StrongRef{URI: testStrongRefURI, CID: testStrongRefCID},
+			Expected: true,
+		},
+		{
+			Ref:   aturi.StrongRef{URI: "at://Example.COM/app.bsky.feed.post/3jui7kd54zh2y", CID: testStrongRefCID},
+			Other: aturi.StrongRef{URI: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y", CID: testStrongRefCID},
+			Expected: true,
+		},
+		{
+			Ref:   aturi.StrongRef{URI: testStrongRefURI, CID: testStrongRefCID},
+			Other: aturi.StrongRef{URI: testStrongRefURI, CID: "bafkreibme22gw2h7y2h7tg2fhqotaqjucnbc24deqo72b6mkl2egezxhvy"},
+			Expected: false,
+		},
+		{
+			Ref:   aturi.StrongRef{URI: "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y", CID: testStrongRefCID},
+			Other: aturi.StrongRef{URI: "at://did:plc:SCEWMN2PL3OZ36MXME2B6CZZ/app.bsky.feed.post/3jui7kd54zh2y", CID: testStrongRefCID},
+			Expected: false,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.Expected, test.Ref.Equal(test.Other); expected != actual {
+			t.Errorf("For test #%d, the actual result of Equal() is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %t", expected)
+			t.Logf("ACTUAL:   %t", actual)
+			t.Logf("REF:   %#v", test.Ref)
+			t.Logf("OTHER: %#v", test.Other)
+			continue
+		}
+	}
+}
+
+func TestStrongRef_json(t *testing.T) {
+
+	var ref = aturi.StrongRef{URI: testStrongRefURI, CID: testStrongRefCID}
+
+	data, err := json.Marshal(ref)
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	{
+		expected := `{"uri":"` + testStrongRefURI + `","cid":"` + testStrongRefCID + `"}`
+		actual := string(data)
+
+		if expected != actual {
+			t.Errorf("The actual JSON is not what was expected.")
+			t.Logf("EXPECTED: %s", expected)
+			t.Logf("ACTUAL:   %s", actual)
+		}
+	}
+
+	{
+		var actual aturi.StrongRef
+
+		err := json.Unmarshal([]byte(`{"$type":"com.atproto.repo.strongRef","cid":"`+testStrongRefCID+`","uri":"`+testStrongRefURI+`"}`), &actual)
+		if nil != err {
+			t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+		}
+
+		if expected := ref; expected != actual {
+			t.Errorf("The actual unmarshaled strong-ref is not what was expected.")
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+		}
+	}
+
+	{
+		var actual aturi.StrongRef
+
+		err := json.Unmarshal([]byte(`{"uri":"`+testStrongRefURI+`","cid":"not-a-cid"}`), &actual)
+		if nil == err {
+			t.Errorf("Expected an error when unmarshaling an invalid strong-ref but did not actually get one.")
+		}
+	}
+
+	if _, err := json.Marshal(aturi.StrongRef{URI: testStrongRefURI}); nil == err {
+		t.Errorf("Expected an error when marshaling an invalid strong-ref but did not actually get one.")
+	}
+	if _, err := json.Marshal(struct{
+		Subject aturi.StrongRef `json:"subject"`
+	}{}); nil == err {
+		t.Errorf("Expected an error when marshaling the zero strong-ref but did not actually get one.")
+	}
+
+	{
+		var value = struct{
+			Reply *aturi.StrongRef `json:"reply,omitempty"`
+		}{}
+
+		data, err := json.Marshal(value)
+		if nil != err {
+			t.Fatalf("Did not expect an error when marshaling a nil *StrongRef but actually got one: (%T) %s", err, err)
+		}
+
+		if expected, actual := `{}`, string(data); expected != actual {
+			t.Errorf("The actual JSON of a nil *StrongRef with omitempty is not what was expected.")
+			t.Logf("EXPECTED: %s", expected)
+			t.Logf("ACTUAL:   %s", actual)
+		}
+	}
+
+	{
+		var actual = struct{
+			Subject aturi.StrongRef `json:"subject"`
+		}{}
+		if err := json.Unmarshal([]byte(`{"subject":null}`), &actual); nil != err {
+			t.Fatalf("Did not expect an error when unmarshaling a null strong-ref but actually got one: (%T) %s", err, err)
+		}
+
+		if expected := (aturi.StrongRef{}); expected != actual.Subject {
+			t.Errorf("The actual unmarshaled null strong-ref is not what was expected.")
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual.Subject)
+		}
+	}
+}
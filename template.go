@@ -0,0 +1,344 @@
+package aturi
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+	"github.com/reiver/go-nsid"
+)
+
+// Template is a compiled AT-URI template, such as:
+//
+//	at://{did}/app.bsky.feed.generator/{name}
+//	at://{repo:did}/{collection}/{rkey}
+//
+// A template can be expanded into an AT-URI (see [Template.Expand]),
+// and an AT-URI can be matched against a template to get the values of its variables back (see [Template.Match]).
+// So one template can be used both for building AT-URIs and for routing them.
+//
+// A template has an authority, and optionally a collection and an rkey, just like an AT-URI (but no query or fragment).
+// Each of them is either a literal (such as "app.bsky.feed.generator") or a variable (such as "{name}"), never a mix of both.
+//
+// Each variable has a type, which is what its value is validated as.
+// The type may be given after a ':' (such as "{repo:did}").
+// Otherwise, if the name of the variable is a type that is allowed where the variable is (such as "{did}" or "{handle}" in the authority), that is its type.
+// Otherwise it is the default for where the variable is:
+//
+//	type         allowed in    value
+//	─────────────────────────────────────────────────────────
+//	authority    authority     a DID or a handle (the default for the authority)
+//	did          authority     a DID
+//	handle       authority     a handle
+//	nsid         collection    an NSID (the default for the collection)
+//	rkey         rkey          a record-key (the default for the rkey)
+//
+// A variable name is 1 or more of 'A'-'Z', 'a'-'z', '0'-'9', or '_', and may only be used once in a template.
+//
+// A Template is safe to use from many goroutines at the same time.
+type Template struct {
+	raw        string
+	components []templateComponent // the authority, and then (optionally) the collection and the rkey
+}
+
+type templateComponent struct {
+	literal  string
+	variable string // "" if the component is a literal
+	typ      string
+}
+
+// The types of template variables.
+const (
+	templateTypeAuthority = "authority"
+	templateTypeDID       = "did"
+	templateTypeHandle    = "handle"
+	templateTypeNSID      = "nsid"
+	templateTypeRKey      = "rkey"
+)
+
+// templateTypes is the types of variables allowed in each component (the first one is the default).
+var templateTypes = [...][]string{
+	0: {templateTypeAuthority, templateTypeDID, templateTypeHandle},
+	1: {templateTypeNSID},
+	2: {templateTypeRKey},
+}
+
+// CompileTemplate compiles an AT-URI template.
+func CompileTemplate(template string) (*Template, error) {
+	if "" == template {
+		return nil, errEmptyTemplate
+	}
+
+	var str string
+	{
+		const prefix string = "at://"
+
+		if !strings.HasPrefix(template, prefix) {
+			return nil, erorr.Errorf("aturi: template %q does not begin with %q", template, prefix)
+		}
+
+		str = template[len(prefix):]
+	}
+
+	if strings.ContainsAny(str, "?#") {
+		return nil, erorr.Errorf("aturi: template %q may not have a query or fragment", template)
+	}
+
+	var parts []string = strings.Split(str, "/")
+	if len(templateTypes) < len(parts) {
+		return nil, erorr.Errorf("aturi: template %q has more components than an authority, a collection, and an rkey", template)
+	}
+
+	var compiled = Template{
+		raw: template,
+	}
+
+	var names = map[string]struct{}{}
+
+	for index, part := range parts {
+		var component templateComponent
+
+		switch {
+		case "" == part:
+			return nil, erorr.Errorf("aturi: template %q has an empty component", template)
+
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			var name string = part[1 : len(part)-1]
+			var typ string = templateTypes[index][0]
+
+			if colon := strings.IndexByte(name, ':'); 0 <= colon {
+				typ = name[colon+1:]
+				name = name[:colon]
+			} else {
+				for _, t := range templateTypes[index] {
+					if t == name {
+						typ = name
+						break
+					}
+				}
+			}
+
+			if err := validateTemplateVariableName(name); nil != err {
+				return nil, erorr.Errorf("aturi: template %q has a bad variable: %w", template, err)
+			}
+			if _, found := names[name]; found {
+				return nil, erorr.Errorf("aturi: template %q uses the variable %q more than once", template, name)
+			}
+			names[name] = struct{}{}
+
+			var allowed bool
+			for _, t := range templateTypes[index] {
+				if t == typ {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return nil, erorr.Errorf("aturi: template %q has a variable %q with the type %q, but only %q are allowed there", template, name, typ, templateTypes[index])
+			}
+
+			component.variable = name
+			component.typ = typ
+
+		case strings.ContainsAny(part, "{}"):
+			return nil, erorr.Errorf("aturi: template %q has a component %q that mixes a variable with a literal", template, part)
+
+		default:
+			if err := validateTemplateValue(templateTypes[index][0], part); nil != err {
+				return nil, erorr.Errorf("aturi: template %q has a bad literal: %w", template, err)
+			}
+
+			component.literal = part
+		}
+
+		compiled.components = append(compiled.components, component)
+	}
+
+	return &compiled, nil
+}
+
+// MustCompileTemplate is like [CompileTemplate] except it panic()s if there is an error.
+func MustCompileTemplate(template string) *Template {
+	compiled, err := CompileTemplate(template)
+	if nil != err {
+		panic(err)
+	}
+
+	return compiled
+}
+
+func validateTemplateVariableName(name string) error {
+	if "" == name {
+		return erorr.Error("aturi: empty variable name")
+	}
+
+	for index := 0; index < len(name); index++ {
+		var b byte = name[index]
+
+		switch {
+		case 'A' <= b && b <= 'Z':
+		case 'a' <= b && b <= 'z':
+		case '0' <= b && b <= '9':
+		case '_' == b:
+		default:
+			return erorr.Errorf("aturi: character №%d (%q) of variable name %q is not allowed", index, b, name)
+		}
+	}
+
+	return nil
+}
+
+// validateTemplateValue returns an error if the value is not valid for the template variable type.
+func validateTemplateValue(typ string, value string) error {
+	switch typ {
+	case templateTypeAuthority:
+		if strings.HasPrefix(value, "did:") {
+			return validateDID(value)
+		}
+		return validateHandle(value)
+	case templateTypeDID:
+		return validateDID(value)
+	case templateTypeHandle:
+		return validateHandle(value)
+	case templateTypeNSID:
+		return nsid.Validate(value)
+	case templateTypeRKey:
+		return validateRecordKey(value)
+	default:
+		return erorr.Errorf("aturi: unknown template variable type %q", typ)
+	}
+}
+
+// String returns the template that was compiled.
+func (receiver *Template) String() string {
+	if nil == receiver {
+		return ""
+	}
+	return receiver.raw
+}
+
+// Variables returns the names of the variables of the template, in the order they are in the template.
+func (receiver *Template) Variables() []string {
+	if nil == receiver {
+		return nil
+	}
+
+	var names []string
+	for _, component := range receiver.components {
+		if "" != component.variable {
+			names = append(names, component.variable)
+		}
+	}
+	return names
+}
+
+// Expand returns the AT-URI made by replacing each variable of the template with its value.
+//
+// Each value is validated as the type of its variable.
+// Every variable must have a value. Values for names that are not variables of the template are ignored.
+//
+// For example:
+//
+//	var template = aturi.MustCompileTemplate("at://{did}/app.bsky.feed.generator/{name}")
+//	
+//	uri, err := template.Expand(map[string]string{
+//		"did":  "did:plc:scewmn2pl3oz36mxme2b6czz",
+//		"name": "whats-hot",
+//	})
+//	
+//	// uri == "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats-hot"
+func (receiver *Template) Expand(values map[string]string) (string, error) {
+	if nil == receiver {
+		return "", errNilReceiver
+	}
+
+	var expanded [len(templateTypes)]string
+
+	for index, component := range receiver.components {
+		if "" == component.variable {
+			expanded[index] = component.literal
+			continue
+		}
+
+		value, found := values[component.variable]
+		if !found {
+			return "", erorr.Errorf("aturi: no value for the variable %q of template %q", component.variable, receiver.raw)
+		}
+
+		if err := validateTemplateValue(component.typ, value); nil != err {
+			return "", erorr.Errorf("aturi: value %q for the variable %q of template %q is not a valid %s: %w", value, component.variable, receiver.raw, component.typ, err)
+		}
+
+		expanded[index] = value
+	}
+
+	return Join(expanded[0], expanded[1], expanded[2], "", ""), nil
+}
+
+// Match returns the values of the variables of the template, if the AT-URI matches the template.
+// Otherwise it returns false.
+//
+// To match, the AT-URI must have the same components as the template (and no query, fragment, or trailing slash),
+// each literal must be the same,
+// and each variable's value must be valid for the type of the variable.
+//
+// For example:
+//
+//	var template = aturi.MustCompileTemplate("at://{did}/app.bsky.feed.generator/{name}")
+//	
+//	values, matched := template.Match("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats-hot")
+//	
+//	// matched        == true
+//	// values["did"]  == "did:plc:scewmn2pl3oz36mxme2b6czz"
+//	// values["name"] == "whats-hot"
+func (receiver *Template) Match(uri string) (map[string]string, bool) {
+	return receiver.MatchWith(Parser{}, uri)
+}
+
+// MatchWith is like [Template.Match], but uses the [Parser] (and so its options and [Policy]) instead of [Split].
+// An AT-URI that the Parser returns an error for does not match.
+func (receiver *Template) MatchWith(parser Parser, uri string) (map[string]string, bool) {
+	if nil == receiver {
+		return nil, false
+	}
+
+	authority, collection, rkey, query, fragment, err := parser.Split(uri)
+	if nil != err {
+		return nil, false
+	}
+	if strings.ContainsAny(uri, "?#") || "" != query || "" != fragment || strings.HasSuffix(uri, "/") {
+		return nil, false
+	}
+
+	var actual = [len(templateTypes)]string{authority, collection, rkey}
+
+	var length int
+	for index, value := range actual {
+		if "" != value {
+			length = index + 1
+		}
+	}
+	if len(receiver.components) != length {
+		return nil, false
+	}
+
+	var values = map[string]string{}
+
+	for index, component := range receiver.components {
+		var value string = actual[index]
+
+		if "" == component.variable {
+			if component.literal != value {
+				return nil, false
+			}
+			continue
+		}
+
+		if err := validateTemplateValue(component.typ, value); nil != err {
+			return nil, false
+		}
+
+		values[component.variable] = value
+	}
+
+	return values, true
+}
@@ -0,0 +1,284 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"fmt"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestTemplate(t *testing.T) {
+
+	tests := []struct{
+		Template string
+		Values map[string]string
+		Expected string
+		ExpectedVariables []string
+	}{
+		{
+			Template: "at://{did}/app.bsky.feed.generator/{name}",
+			Values: map[string]string{
+				"did":  "did:plc:scewmn2pl3oz36mxme2b6czz",
+				"name": "whats-hot",
+			},
+			Expected:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats-hot",
+			ExpectedVariables: []string{"did", "name"},
+		},
+		{
+			Template: "at://{actor}/app.bsky.feed.generator/{name}",
+			Values: map[string]string{
+				"actor": "reiver.bsky.social",
+				"name":  "whats-hot",
+				"extra": "ignored",
+			},
+			Expected:          "at://reiver.bsky.social/app.bsky.feed.generator/whats-hot",
+			ExpectedVariables: []string{"actor", "name"},
+		},
+		{
+			Template: "at://{repo:did}/{collection}/{rkey}",
+			Values: map[string]string{
+				"repo":       "did:plc:scewmn2pl3oz36mxme2b6czz",
+				"collection": "app.bsky.feed.post",
+				"rkey":       "3jui7kd54zh2y",
+			},
+			Expected:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedVariables: []string{"repo", "collection", "rkey"},
+		},
+		{
+			Template: "at://did:plc:scewmn2pl3oz36mxme2b6czz/{collection}",
+			Values: map[string]string{
+				"collection": "app.bsky.graph.follow",
+			},
+			Expected:          "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.graph.follow",
+			ExpectedVariables: []string{"collection"},
+		},
+		{
+			Template: "at://{handle:handle}",
+			Values: map[string]string{
+				"handle": "reiver.bsky.social",
+			},
+			Expected:          "at://reiver.bsky.social",
+			ExpectedVariables: []string{"handle"},
+		},
+		{
+			Template:          "at://bsky.app/app.bsky.actor.profile/self",
+			Values:            nil,
+			Expected:          "at://bsky.app/app.bsky.actor.profile/self",
+			ExpectedVariables: nil,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		template, err := aturi.CompileTemplate(test.Template)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("TEMPLATE: %q", test.Template)
+			continue
+		}
+
+		if expected, actual := fmt.Sprintf("%q", test.ExpectedVariables), fmt.Sprintf("%q", template.Variables()); expected != actual {
+			t.Errorf("For test #%d, the actual variables are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %s", expected)
+			t.Logf("ACTUAL:   %s", actual)
+			t.Logf("TEMPLATE: %q", test.Template)
+			continue
+		}
+
+		uri, err := template.Expand(test.Values)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("TEMPLATE: %q", test.Template)
+			continue
+		}
+
+		if expected, actual := test.Expected, uri; expected != actual {
+			t.Errorf("For test #%d, the actual expanded AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("TEMPLATE: %q", test.Template)
+			continue
+		}
+
+		values, matched := template.Match(uri)
+		if !matched {
+			t.Errorf("For test #%d, expected the expanded AT-URI to match the template but it actually did not.", testNumber)
+			t.Logf("URI:      %q", uri)
+			t.Logf("TEMPLATE: %q", test.Template)
+			continue
+		}
+
+		for _, name := range test.ExpectedVariables {
+			if expected, actual := test.Values[name], values[name]; expected != actual {
+				t.Errorf("For test #%d, the actual value of variable %q from Match() is not what was expected.", testNumber, name)
+				t.Logf("EXPECTED: %q", expected)
+				t.Logf("ACTUAL:   %q", actual)
+				t.Logf("TEMPLATE: %q", test.Template)
+			}
+		}
+		if expected, actual := len(test.ExpectedVariables), len(values); expected != actual {
+			t.Errorf("For test #%d, the actual number of values from Match() is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+	}
+}
+
+func TestCompileTemplate_fail(t *testing.T) {
+
+	tests := []string{
+		"",
+		"https://{host}/",
+		"AT://{did}",
+		"at://{did}/app.bsky.feed.post/{rkey}?{query}",
+		"at://{did}/app.bsky.feed.post/{rkey}#frag",
+		"at://{did}/app.bsky.feed.post/{rkey}/{extra}",
+		"at://{did}//{rkey}",
+		"at://{did}/app.bsky.feed.{type}/{rkey}",
+		"at://{did}/app.bsky.feed.post/{rkey",
+		"at://{}/app.bsky.feed.post",
+		"at://{my-did}/app.bsky.feed.post",
+		"at://{did}/{did}",
+		"at://{did:nsid}/app.bsky.feed.post",
+		"at://{did}/{collection:rkey}",
+		"at://{did}/app.bsky.feed.post/{rkey:tid}",
+		"at://example/app.bsky.feed.post",
+		"at://{did}/foorBar",
+		"at://{did}/app.bsky.feed.post/..",
+	}
+
+	for testNumber, test := range tests {
+
+		if _, err := aturi.CompileTemplate(test); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("TEMPLATE: %q", test)
+			continue
+		}
+	}
+}
+
+func TestTemplate_Expand_fail(t *testing.T) {
+
+	var template = aturi.MustCompileTemplate("at://{repo:did}/app.bsky.feed.generator/{name}")
+
+	tests := []map[string]string{
+		nil,
+		{"repo": "did:plc:scewmn2pl3oz36mxme2b6czz"},
+		{"repo": "reiver.bsky.social", "name": "whats-hot"},
+		{"repo": "did:plc:scewmn2pl3oz36mxme2b6czz", "name": "whats/hot"},
+		{"repo": "did:plc:scewmn2pl3oz36mxme2b6czz", "name": ""},
+	}
+
+	for testNumber, test := range tests {
+
+		if actual, err := template.Expand(test); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("VALUES: %#v", test)
+			t.Logf("ACTUAL: %q", actual)
+			continue
+		}
+	}
+}
+
+func TestTemplate_Match_fail(t *testing.T) {
+
+	var template = aturi.MustCompileTemplate("at://{repo:did}/app.bsky.feed.generator/{name}")
+
+	tests := []string{
+		"",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/whats-hot",
+		"at://reiver.bsky.social/app.bsky.feed.generator/whats-hot",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats-hot?x=1",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats-hot#frag",
+		"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats/hot",
+	}
+
+	for testNumber, test := range tests {
+
+		if values, matched := template.Match(test); matched {
+			t.Errorf("For test #%d, did not expect the AT-URI to match but it actually did.", testNumber)
+			t.Logf("URI: %q", test)
+			t.Logf("VALUES: %#v", values)
+			continue
+		}
+	}
+}
+
+func TestTemplate_typeFromName(t *testing.T) {
+
+	tests := []struct{
+		Template string
+		URI string
+		ExpectedMatch bool
+	}{
+		{
+			Template:      "at://{did}/app.bsky.feed.generator/{name}",
+			URI:           "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.generator/whats-hot",
+			ExpectedMatch: true,
+		},
+		{
+			Template:      "at://{did}/app.bsky.feed.generator/{name}",
+			URI:           "at://reiver.bsky.social/app.bsky.feed.generator/whats-hot",
+			ExpectedMatch: false,
+		},
+		{
+			Template:      "at://{handle}",
+			URI:           "at://reiver.bsky.social",
+			ExpectedMatch: true,
+		},
+		{
+			Template:      "at://{handle}",
+			URI:           "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+			ExpectedMatch: false,
+		},
+		{
+			Template:      "at://{authority}",
+			URI:           "at://did:plc:scewmn2pl3oz36mxme2b6czz",
+			ExpectedMatch: true,
+		},
+		{
+			Template:      "at://{authority}",
+			URI:           "at://reiver.bsky.social",
+			ExpectedMatch: true,
+		},
+		{
+			// "did" is not a type that is allowed in the rkey, so it is just a name there.
+			Template:      "at://{repo}/app.bsky.feed.post/{did}",
+			URI:           "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedMatch: true,
+		},
+		{
+			// An explicit type wins over the name.
+			Template:      "at://{did:authority}",
+			URI:           "at://reiver.bsky.social",
+			ExpectedMatch: true,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		template, err := aturi.CompileTemplate(test.Template)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("TEMPLATE: %q", test.Template)
+			continue
+		}
+
+		if _, actual := template.Match(test.URI); test.ExpectedMatch != actual {
+			t.Errorf("For test #%d, the actual 'matched' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %t", test.ExpectedMatch)
+			t.Logf("ACTUAL:   %t", actual)
+			t.Logf("TEMPLATE: %q", test.Template)
+			t.Logf("URI:      %q", test.URI)
+			continue
+		}
+	}
+}
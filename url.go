@@ -0,0 +1,143 @@
+package aturi
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// ToURL returns the AT-URI as a *url.URL.
+//
+// url.Parse mangles many AT-URIs, because it reads the colons in a DID authority (such as "did:plc:1234") as a host and a port.
+// ToURL does not.
+//
+// If the AT-URI can be put in the Host and Path of a url.URL, such that url.URL.String returns the AT-URI as is
+// (which is the case for most AT-URIs with a handle authority), then it is.
+// Otherwise (such as for a DID authority) everything between the "at:" and the query is put in Opaque, as in:
+//
+//	&url.URL{
+//		Scheme: "at",
+//		Opaque: "//did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+//	}
+//
+// Either way, the query and fragment are carried over as they are.
+// And [FromURL] returns the original AT-URI.
+//
+// The one thing a url.URL cannot hold is an empty fragment (a '#' at the very end of the AT-URI).
+// It is dropped.
+//
+// ToURL returns an error if [Split] does.
+func ToURL(uri string) (*url.URL, error) {
+	authority, _, _, query, fragment, err := Split(uri)
+	if nil != err {
+		return nil, err
+	}
+
+	var scheme string = uri[:len("at")]
+	var rest string = uri[len("at:"):] // "//" followed by the authority, path, query, and fragment
+
+	var opaque string = rest
+	var hasQuery bool
+	if index := strings.IndexAny(rest, "?#"); 0 <= index {
+		opaque = rest[:index]
+		hasQuery = '?' == rest[index]
+	}
+
+	var u = url.URL{
+		Scheme:     scheme,
+		RawQuery:   query,
+		ForceQuery: hasQuery && "" == query,
+	}
+	if unescaped, err := url.PathUnescape(fragment); nil == err {
+		u.Fragment = unescaped
+		u.RawFragment = fragment
+	} else {
+		u.Fragment = fragment
+	}
+
+	if !strings.HasPrefix(authority, "did:") {
+		var hostForm url.URL = u
+
+		var path string = opaque[len("//")+len(authority):]
+
+		hostForm.Host = authority
+		if unescaped, err := url.PathUnescape(path); nil == err {
+			hostForm.Path = unescaped
+			hostForm.RawPath = path
+
+			if uri == hostForm.String() {
+				return &hostForm, nil
+			}
+		}
+	}
+
+	u.Opaque = opaque
+	return &u, nil
+}
+
+// FromURL returns the AT-URI that the *url.URL holds.
+//
+// FromURL is the inverse of [ToURL].
+// It also works with a *url.URL from url.Parse, but only if url.Parse did not mangle it.
+//
+// The url.URL must not have a user (since an AT-URI may not have an '@' in its authority).
+//
+// FromURL returns an error if [Split] would for the AT-URI.
+func FromURL(u *url.URL) (string, error) {
+	if nil == u {
+		return "", errNilURL
+	}
+
+	if !strings.EqualFold("at", u.Scheme) {
+		return "", newError(ErrorKindScheme, u.String(), erorr.Errorf("aturi: URL %q does not have the scheme %q", u.String(), "at"))
+	}
+	if nil != u.User {
+		return "", newError(ErrorKindAuthority, u.String(), erorr.Errorf("aturi: URL %q has a user, but an AT-URI may not have an %q in its authority", u.String(), "@"))
+	}
+
+	var buffer strings.Builder
+
+	buffer.WriteString(u.Scheme)
+	buffer.WriteString(":")
+
+	switch {
+	case "" != u.Opaque:
+		if !strings.HasPrefix(u.Opaque, "//") {
+			return "", newError(ErrorKindScheme, u.String(), erorr.Errorf("aturi: URL %q is not an AT-URI because its opaque part does not begin with %q", u.String(), "//"))
+		}
+		buffer.WriteString(u.Opaque)
+	default:
+		buffer.WriteString("//")
+		buffer.WriteString(u.Host)
+
+		switch {
+		case "" != u.RawPath:
+			buffer.WriteString(u.RawPath)
+		default:
+			buffer.WriteString(u.EscapedPath())
+		}
+	}
+
+	if "" != u.RawQuery || u.ForceQuery {
+		buffer.WriteString("?")
+		buffer.WriteString(u.RawQuery)
+	}
+
+	switch {
+	case "" != u.RawFragment:
+		buffer.WriteString("#")
+		buffer.WriteString(u.RawFragment)
+	case "" != u.Fragment:
+		buffer.WriteString("#")
+		buffer.WriteString(u.EscapedFragment())
+	}
+
+	var uri string = buffer.String()
+
+	if _, _, _, _, _, err := Split(uri); nil != err {
+		return "", err
+	}
+
+	return uri, nil
+}
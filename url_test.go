@@ -0,0 +1,206 @@
+package aturi_test
+
+import (
+	"testing"
+
+	"net/url"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+)
+
+func TestToURL(t *testing.T) {
+
+	tests := []struct{
+		URI string
+		ExpectedHost string
+		ExpectedOpaque string
+		ExpectedQuery string
+		ExpectedFragment string
+	}{
+		{
+			URI:          "at://example.com/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedHost: "example.com",
+		},
+		{
+			URI:          "at://reiver.bsky.social/app.bsky.feed.post/3jui7kd54zh2y?once=1&twice=2#frag%20ment",
+			ExpectedHost: "reiver.bsky.social",
+			ExpectedQuery: "once=1&twice=2",
+			ExpectedFragment: "frag ment",
+		},
+		{
+			URI:            "at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedOpaque: "//did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			// the identifier looks like a port
+			URI:            "at://did:plc:1234/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedOpaque: "//did:plc:1234/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+		{
+			// the identifier looks like a host and a port
+			URI:            "at://did:web:localhost:8080?once=1#frag",
+			ExpectedOpaque: "//did:web:localhost:8080",
+			ExpectedQuery:    "once=1",
+			ExpectedFragment: "frag",
+		},
+		{
+			URI:            "at://did:web:localhost%3A8080/app.bsky.feed.post/3jui7kd54zh2y",
+			ExpectedOpaque: "//did:web:localhost%3A8080/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		u, err := aturi.ToURL(test.URI)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected, actual := test.ExpectedHost, u.Host; expected != actual {
+			t.Errorf("For test #%d, the actual 'host' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+		if expected, actual := test.ExpectedOpaque, u.Opaque; expected != actual {
+			t.Errorf("For test #%d, the actual 'opaque' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+		if expected, actual := test.ExpectedQuery, u.RawQuery; expected != actual {
+			t.Errorf("For test #%d, the actual 'query' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+		if expected, actual := test.ExpectedFragment, u.Fragment; expected != actual {
+			t.Errorf("For test #%d, the actual 'fragment' is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected, actual := test.URI, u.String(); expected != actual {
+			t.Errorf("For test #%d, the actual (url.URL).String() is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestToURL_roundTrip(t *testing.T) {
+
+	for testNumber, test := range splitTests {
+
+		// A url.URL cannot hold an empty fragment, so it is dropped.
+		var expectedURI string = test.URI
+		if "" == test.ExpectedFragment {
+			expectedURI = strings.TrimSuffix(expectedURI, "#")
+		}
+
+		u, err := aturi.ToURL(test.URI)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected, actual := expectedURI, u.String(); expected != actual {
+			t.Errorf("For test #%d, the actual (url.URL).String() is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URL: %#v", u)
+			continue
+		}
+
+		actual, err := aturi.FromURL(u)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("URI: %q", test.URI)
+			continue
+		}
+
+		if expected := expectedURI; expected != actual {
+			t.Errorf("For test #%d, the actual AT-URI from FromURL() is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			t.Logf("URL: %#v", u)
+			continue
+		}
+	}
+}
+
+func TestFromURL_urlParse(t *testing.T) {
+
+	tests := []struct{
+		URL string
+		Expected string
+	}{
+		{
+			URL:      "at://example.com/app.bsky.feed.post/3jui7kd54zh2y?once=1#frag",
+			Expected: "at://example.com/app.bsky.feed.post/3jui7kd54zh2y?once=1#frag",
+		},
+		{
+			// url.Parse reads this as the host "did:plc" and the port "1234".
+			URL:      "at://did:plc:1234/app.bsky.feed.post/3jui7kd54zh2y",
+			Expected: "at://did:plc:1234/app.bsky.feed.post/3jui7kd54zh2y",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		u, err := url.Parse(test.URL)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		actual, err := aturi.FromURL(u)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected := test.Expected; expected != actual {
+			t.Errorf("For test #%d, the actual AT-URI is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestFromURL_fail(t *testing.T) {
+
+	tests := []*url.URL{
+		nil,
+		&url.URL{Scheme: "https", Host: "example.com"},
+		&url.URL{Scheme: "at", Host: "example.com", User: url.User("joeblow")},
+		&url.URL{Scheme: "at", Opaque: "example.com"},
+		&url.URL{Scheme: "at", Host: ""},
+		&url.URL{Scheme: "at", Host: "example.com", Path: "/foorBar"},
+	}
+
+	for testNumber, u := range tests {
+
+		if _, err := aturi.FromURL(u); nil == err {
+			t.Errorf("For test #%d, expected an error but did not actually get one.", testNumber)
+			t.Logf("URL: %#v", u)
+			continue
+		}
+	}
+}
@@ -2,7 +2,10 @@ package aturi
 
 // Validate returns an error if the AT-URI is invalid.
 // It returns nil if the AT-URI is valid.
+//
+// Validate is a wrapper around a zero-value [Parser].
+// To use different limits, or to be more strict, use a [Parser].
 func Validate(uri string) error {
-	_, _, _, _, _, err := Split(uri)
+	_, _, _, _, _, err := split(uri)
 	return err
 }
@@ -0,0 +1,82 @@
+package aturi
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+
+	"github.com/reiver/go-erorr"
+)
+
+// base32Lower is the multibase "base32" encoding (multibase prefix 'b'): RFC 4648 base32, lower-case, no padding.
+var base32Lower = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// validateCID returns an error if the CID is not a syntactically valid CIDv1 in its multibase base32 string form,
+// such as "bafyreidfayvfuwqa7qlnopdjiqrxzs6blmoeu4rujcjtnci5beludirz2a".
+//
+// The decoded bytes must be:
+// the version (a varint that must be 1),
+// the codec (a varint),
+// and a multihash — the hash function code (a varint), the digest length (a varint), and exactly that many digest bytes.
+func validateCID(cid string) error {
+	if "" == cid {
+		return errEmptyCID
+	}
+
+	{
+		const max int = 256
+
+		var length int = len(cid)
+
+		if max < length {
+			return erorr.Errorf("aturi: CID %q is %d characters long but a CID may not be more than %d characters long", cid, length, max)
+		}
+	}
+
+	{
+		const prefix byte = 'b'
+
+		if prefix != cid[0] {
+			return erorr.Errorf("aturi: CID %q is not a multibase base32 CIDv1 because it does not begin with %q", cid, prefix)
+		}
+	}
+
+	data, err := base32Lower.DecodeString(cid[1:])
+	if nil != err {
+		return erorr.Errorf("aturi: CID %q is not valid base32: %w", cid, err)
+	}
+
+	var next = func(name string) (uint64, error) {
+		value, size := binary.Uvarint(data)
+		if size <= 0 {
+			return 0, erorr.Errorf("aturi: CID %q has a bad %s varint", cid, name)
+		}
+		data = data[size:]
+		return value, nil
+	}
+
+	version, err := next("version")
+	if nil != err {
+		return err
+	}
+	if 1 != version {
+		return erorr.Errorf("aturi: CID %q has version %d but only version 1 is allowed", cid, version)
+	}
+
+	if _, err := next("codec"); nil != err {
+		return err
+	}
+
+	if _, err := next("multihash code"); nil != err {
+		return err
+	}
+
+	digestLength, err := next("multihash length")
+	if nil != err {
+		return err
+	}
+	if uint64(len(data)) != digestLength {
+		return erorr.Errorf("aturi: CID %q has a multihash digest that is %d bytes long but it says it is %d bytes long", cid, len(data), digestLength)
+	}
+
+	return nil
+}
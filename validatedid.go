@@ -0,0 +1,90 @@
+package aturi
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// validateDID returns an error if the DID is not syntactically valid.
+//
+// A DID looks like "did:<method>:<identifier>" where:
+// the whole DID is at most 2048 characters long,
+// the method is 1 or more lower-case letters ('a'-'z'),
+// and the identifier is 1 or more of 'A'-'Z', 'a'-'z', '0'-'9', '.', '_', ':', '%', or '-' that does not end with a ':' or '%'.
+func validateDID(did string) error {
+	if "" == did {
+		return errEmptyDID
+	}
+
+	{
+		const max int = 2048
+
+		var length int = len(did)
+
+		if max < length {
+			return erorr.Errorf("aturi: DID %q is %d characters long but a DID may not be more than %d characters long", did, length, max)
+		}
+	}
+
+	var str string = did
+	{
+		const prefix string = "did:"
+
+		if !strings.HasPrefix(str, prefix) {
+			return erorr.Errorf("aturi: DID %q does not begin with %q", did, prefix)
+		}
+
+		str = str[len(prefix):]
+	}
+
+	// method
+	{
+		var index int = strings.IndexByte(str, ':')
+		if index < 0 {
+			return erorr.Errorf("aturi: DID %q is missing the ':' between its method and its identifier", did)
+		}
+
+		var method string = str[:index]
+		if "" == method {
+			return erorr.Errorf("aturi: DID %q has an empty method", did)
+		}
+
+		for index := 0; index < len(method); index++ {
+			var b byte = method[index]
+
+			if b < 'a' || 'z' < b {
+				return erorr.Errorf("aturi: character №%d (%q) of method %q of DID %q is not a lower-case letter ('a'-'z')", index, b, method, did)
+			}
+		}
+
+		str = str[index+1:]
+	}
+
+	// identifier
+	{
+		var identifier string = str
+		if "" == identifier {
+			return erorr.Errorf("aturi: DID %q has an empty identifier", did)
+		}
+
+		for index := 0; index < len(identifier); index++ {
+			var b byte = identifier[index]
+
+			switch {
+			case 'A' <= b && b <= 'Z':
+			case 'a' <= b && b <= 'z':
+			case '0' <= b && b <= '9':
+			case '.' == b, '_' == b, '-' == b:
+			case ':' == b, '%' == b:
+				if len(identifier)-1 == index {
+					return erorr.Errorf("aturi: DID %q may not end with a %q", did, b)
+				}
+			default:
+				return erorr.Errorf("aturi: character №%d (%q) of identifier %q of DID %q is not allowed", index, b, identifier, did)
+			}
+		}
+	}
+
+	return nil
+}
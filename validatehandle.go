@@ -0,0 +1,94 @@
+package aturi
+
+import (
+	"github.com/reiver/go-erorr"
+)
+
+// validateHandle returns an error if the handle is not syntactically valid.
+//
+// A handle is a domain name where:
+// the whole handle is at most 253 characters long,
+// there are at least 2 labels,
+// each label is 1 to 63 characters of 'A'-'Z', 'a'-'z', '0'-'9', or '-' that does not begin or end with a '-',
+// and the last label (the TLD) begins with a letter.
+func validateHandle(handle string) error {
+	if "" == handle {
+		return errEmptyHandle
+	}
+
+	{
+		const max int = 253
+
+		var length int = len(handle)
+
+		if max < length {
+			return erorr.Errorf("aturi: handle %q is %d characters long but a handle may not be more than %d characters long", handle, length, max)
+		}
+	}
+
+	var numLabels int
+	var label string
+	{
+		var begin int = 0
+
+		for index := 0; index <= len(handle); index++ {
+			if index < len(handle) && '.' != handle[index] {
+				continue
+			}
+
+			label = handle[begin:index]
+			numLabels++
+
+			if err := validateHandleLabel(label, handle); nil != err {
+				return err
+			}
+
+			begin = index+1
+		}
+	}
+
+	if numLabels < 2 {
+		return erorr.Errorf("aturi: handle %q should have at least 2 labels but actually has %d", handle, numLabels)
+	}
+
+	{
+		var b byte = label[0]
+
+		if !('A' <= b && b <= 'Z') && !('a' <= b && b <= 'z') {
+			return erorr.Errorf("aturi: the last label %q of handle %q does not begin with a letter ('A'-'Z', 'a'-'z')", label, handle)
+		}
+	}
+
+	return nil
+}
+
+func validateHandleLabel(label string, handle string) error {
+	{
+		const min int = 1
+		const max int = 63
+
+		var length int = len(label)
+
+		if length < min || max < length {
+			return erorr.Errorf("aturi: label %q of handle %q is %d characters long but a label must be between %d and %d characters long", label, handle, length, min, max)
+		}
+	}
+
+	for index := 0; index < len(label); index++ {
+		var b byte = label[index]
+
+		switch {
+		case 'A' <= b && b <= 'Z':
+		case 'a' <= b && b <= 'z':
+		case '0' <= b && b <= '9':
+		case '-' == b:
+			if 0 == index || len(label)-1 == index {
+				return erorr.Errorf("aturi: label %q of handle %q may not begin or end with a %q", label, handle, b)
+			}
+		default:
+			return erorr.Errorf("aturi: character №%d (%q) of label %q of handle %q is not a letter ('A'-'Z', 'a'-'z'), a digit ('0'-'9'), or a hyphen ('-')", index, b, label, handle)
+		}
+	}
+
+	return nil
+}
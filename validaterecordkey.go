@@ -0,0 +1,45 @@
+package aturi
+
+import (
+	"github.com/reiver/go-erorr"
+)
+
+// validateRecordKey returns an error if the record-key (rkey) is not syntactically valid.
+//
+// A record-key is 1 to 512 characters of 'A'-'Z', 'a'-'z', '0'-'9', '.', '-', '_', ':', or '~',
+// and may not be "." or "..".
+func validateRecordKey(rkey string) error {
+	if "" == rkey {
+		return errEmptyRecordKey
+	}
+
+	{
+		const max int = 512
+
+		var length int = len(rkey)
+
+		if max < length {
+			return erorr.Errorf("aturi: record-key %q is %d characters long but a record-key may not be more than %d characters long", rkey, length, max)
+		}
+	}
+
+	switch rkey {
+	case ".", "..":
+		return erorr.Errorf("aturi: record-key may not be %q", rkey)
+	}
+
+	for index := 0; index < len(rkey); index++ {
+		var b byte = rkey[index]
+
+		switch {
+		case 'A' <= b && b <= 'Z':
+		case 'a' <= b && b <= 'z':
+		case '0' <= b && b <= '9':
+		case '.' == b, '-' == b, '_' == b, ':' == b, '~' == b:
+		default:
+			return erorr.Errorf("aturi: character №%d (%q) of record-key %q is not allowed", index, b, rkey)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package aturi
+
+import (
+	"strings"
+
+	"github.com/reiver/go-erorr"
+)
+
+// ValidateStrict returns an error if the AT-URI is invalid under the stricter rules
+// used for 'at-uri' fields in AT-protocol records.
+// It returns nil if the AT-URI is valid.
+//
+// On top of what [Validate] checks, ValidateStrict also requires that:
+// the scheme is a lower-case "at://",
+// the authority is a valid handle or DID,
+// an rkey only comes after a collection,
+// the rkey is a valid record-key,
+// there is no trailing slash,
+// and there is no query or fragment.
+func ValidateStrict(uri string) error {
+	authority, collection, rkey, _, _, err := Split(uri)
+	if nil != err {
+		return err
+	}
+
+	{
+		const prefix string = "at://"
+
+		if !strings.HasPrefix(uri, prefix) {
+			return newError(ErrorKindScheme, uri, erorr.Errorf("aturi: URI %q does not begin with a lower-case %q", uri, prefix))
+		}
+	}
+
+	if strings.Contains(uri, "?") {
+		return newError(ErrorKindQuery, uri, erorr.Errorf("aturi: URI %q may not have a query", uri))
+	}
+	if strings.Contains(uri, "#") {
+		return newError(ErrorKindFragment, uri, erorr.Errorf("aturi: URI %q may not have a fragment", uri))
+	}
+
+	if strings.HasSuffix(uri, "/") {
+		return newError(ErrorKindPath, uri, erorr.Errorf("aturi: URI %q may not end with a %q", uri, "/"))
+	}
+
+	// authority
+	{
+		var err error
+
+		switch {
+		case strings.HasPrefix(authority, "did:"):
+			err = validateDID(authority)
+		default:
+			err = validateHandle(authority)
+		}
+
+		if nil != err {
+			return newError(ErrorKindAuthority, uri, erorr.Errorf("aturi: URI %q has an authority %q that is neither a valid DID nor a valid handle: %w", uri, authority, err))
+		}
+	}
+
+	// collection
+	if "" == collection && "" != rkey {
+		return newError(ErrorKindPath, uri, erorr.Errorf("aturi: URI %q has an rkey %q but no collection", uri, rkey))
+	}
+
+	// rkey
+	if "" != rkey {
+		if err := checkSegments("URI", uri, rkey); nil != err {
+			return err
+		}
+
+		if err := checkRecordKey("URI", uri, rkey); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
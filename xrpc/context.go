@@ -0,0 +1,36 @@
+package xrpc
+
+import (
+	"context"
+)
+
+// contextKey is the key the parsed AT-URI parameters are stored under in the request context.
+type contextKey struct{}
+
+// contextValue is what is stored in the request context: the parsed AT-URIs of each parameter.
+type contextValue map[string][]URI
+
+func withURIs(ctx context.Context, uris contextValue) context.Context {
+	return context.WithValue(ctx, contextKey{}, uris)
+}
+
+// URIFromContext returns the (first) AT-URI of the parameter named 'name', that the [Middleware] parsed.
+// It returns false if there is none (such as if the parameter is optional and was not in the request).
+func URIFromContext(ctx context.Context, name string) (URI, bool) {
+	var uris []URI = URIsFromContext(ctx, name)
+	if len(uris) < 1 {
+		return URI{}, false
+	}
+	return uris[0], true
+}
+
+// URIsFromContext returns every AT-URI of the parameter named 'name' (in the order they were in the request), that the [Middleware] parsed.
+// Use it for parameters that may be repeated (see Param.Multiple), such as the "uris" parameter of "app.bsky.feed.getPosts".
+func URIsFromContext(ctx context.Context, name string) []URI {
+	if nil == ctx {
+		return nil
+	}
+
+	uris, _ := ctx.Value(contextKey{}).(contextValue)
+	return uris[name]
+}
@@ -0,0 +1,29 @@
+package xrpc
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ErrorInvalidRequest is the XRPC error name for a request with bad parameters.
+const ErrorInvalidRequest string = "InvalidRequest"
+
+// ErrorBody is the JSON body of an XRPC error response.
+type ErrorBody struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+// WriteError writes an XRPC error response.
+func WriteError(w http.ResponseWriter, status int, name string, message string) {
+	if nil == w {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorBody{
+		Error:   name,
+		Message: message,
+	})
+}
@@ -0,0 +1,10 @@
+package xrpc
+
+import (
+	"github.com/reiver/go-erorr"
+)
+
+const (
+	errNilHandler = erorr.Error("xrpc: nil handler")
+	errNilRequest = erorr.Error("xrpc: nil request")
+)
@@ -0,0 +1,144 @@
+package xrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/reiver/go-aturi"
+	"github.com/reiver/go-erorr"
+)
+
+// Param is an AT-URI query parameter of an XRPC endpoint.
+type Param struct {
+	Name     string // the name of the query parameter, such as "uri"
+	Optional bool   // whether the parameter may be missing from the request
+	Multiple bool   // whether the parameter may be repeated (such as "?uris=…&uris=…")
+}
+
+// Middleware parses and validates the AT-URI query parameters of XRPC requests.
+//
+// Each AT-URI is split with the Parser (or with [aturi.Split] if there is no Parser).
+// So, how strict the Middleware is (including whether it uses [aturi.ValidateStrict], and any [aturi.Policy])
+// is configured on the Parser.
+//
+// For example:
+//
+//	var middleware = xrpc.Middleware{
+//		Params: []xrpc.Param{{Name: "uri"}},
+//		Parser: &aturi.Parser{
+//			MaxLength: 2048,
+//			Strict:    true,
+//			Policy:    &aturi.Policy{DenyDIDs: blocked},
+//		},
+//	}
+type Middleware struct {
+	Params []Param
+	Parser *aturi.Parser
+}
+
+// Wrap returns an http.Handler that parses and validates the AT-URI parameters, and then calls 'next' with them in the request context
+// (see [URIFromContext] and [URIsFromContext]).
+//
+// If any AT-URI parameter is missing (and not optional), repeated (and not multiple), or invalid,
+// then Wrap writes an HTTP 400 response with an XRPC "InvalidRequest" error body, and does NOT call 'next'.
+//
+// Wrap panic()s if 'next' is nil.
+func (receiver Middleware) Wrap(next http.Handler) http.Handler {
+	if nil == next {
+		panic(errNilHandler)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uris, err := receiver.Parse(r)
+		if nil != err {
+			WriteError(w, http.StatusBadRequest, ErrorInvalidRequest, err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withURIs(r.Context(), uris)))
+	})
+}
+
+// Parse parses and validates the AT-URI parameters of the request, and returns them by parameter name.
+//
+// The error message of a returned error is suitable as the "message" of an XRPC error response.
+// (The messages are worded like the ones the reference PDS gives for bad parameters.)
+// For an invalid AT-URI it is a [*ParamError].
+func (receiver Middleware) Parse(r *http.Request) (map[string][]URI, error) {
+	if nil == r || nil == r.URL {
+		return nil, errNilRequest
+	}
+
+	var query = r.URL.Query()
+
+	var uris = contextValue{}
+
+	for _, param := range receiver.Params {
+		var values []string = query[param.Name]
+
+		switch {
+		case len(values) < 1 && !param.Optional:
+			return nil, erorr.Errorf("Error: Params must have the property %q", param.Name)
+		case 1 < len(values) && !param.Multiple:
+			return nil, erorr.Errorf("Error: Params property %q may only be given once", param.Name)
+		}
+
+		for _, value := range values {
+			uri, err := receiver.parse(value)
+			if nil != err {
+				return nil, &ParamError{
+					Name: param.Name,
+					Err:  err,
+				}
+			}
+
+			uris[param.Name] = append(uris[param.Name], uri)
+		}
+	}
+
+	return uris, nil
+}
+
+func (receiver Middleware) parse(value string) (URI, error) {
+	var parser aturi.Parser
+	if nil != receiver.Parser {
+		parser = *receiver.Parser
+	}
+
+	authority, collection, rkey, query, fragment, err := parser.Split(value)
+	if nil != err {
+		return URI{}, err
+	}
+
+	return URI{
+		Raw:        value,
+		Authority:  authority,
+		Collection: collection,
+		RKey:       rkey,
+		Query:      query,
+		Fragment:   fragment,
+	}, nil
+}
+
+// ParamError is the error for an invalid AT-URI parameter.
+type ParamError struct {
+	Name string // the name of the query parameter
+	Err  error  // the error from parsing the AT-URI; usually an [*aturi.Error]
+}
+
+// Error returns the error message, such as:
+//
+//	Error: Params property "uri" is not a valid at-uri (rkey): aturi: URI "at://…" has an rkey "…" that is not a valid record-key: …
+func (receiver *ParamError) Error() string {
+	var aturiError *aturi.Error
+	if errors.As(receiver.Err, &aturiError) {
+		return fmt.Sprintf("Error: Params property %q is not a valid at-uri (%s): %s", receiver.Name, aturiError.Kind, receiver.Err)
+	}
+	return fmt.Sprintf("Error: Params property %q is not a valid at-uri: %s", receiver.Name, receiver.Err)
+}
+
+// Unwrap returns the error from parsing the AT-URI.
+func (receiver *ParamError) Unwrap() error {
+	return receiver.Err
+}
@@ -0,0 +1,245 @@
+package xrpc_test
+
+import (
+	"testing"
+
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/reiver/go-aturi"
+	"github.com/reiver/go-aturi/xrpc"
+)
+
+func TestMiddleware_Wrap(t *testing.T) {
+
+	var middleware = xrpc.Middleware{
+		Params: []xrpc.Param{
+			{Name: "uri"},
+			{Name: "cursor", Optional: true},
+		},
+		Parser: &aturi.Parser{
+			Strict: true,
+		},
+	}
+
+	var called bool
+	var handler http.Handler = middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+
+		uri, found := xrpc.URIFromContext(r.Context(), "uri")
+		if !found {
+			t.Errorf("Expected the %q parameter to be in the context but it actually is not.", "uri")
+		}
+
+		if expected, actual := "did:plc:scewmn2pl3oz36mxme2b6czz", uri.Authority; expected != actual {
+			t.Errorf("The actual 'authority' is not what was expected.")
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+		}
+		if expected, actual := "3jui7kd54zh2y", uri.RKey; expected != actual {
+			t.Errorf("The actual 'rkey' is not what was expected.")
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+		}
+
+		if _, found := xrpc.URIFromContext(r.Context(), "cursor"); found {
+			t.Errorf("Did not expect the optional %q parameter to be in the context but it actually is.", "cursor")
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var target string = "/xrpc/app.bsky.feed.getLikes?uri=" + url.QueryEscape("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y")
+
+	var recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	if !called {
+		t.Errorf("Expected the wrapped handler to be called but it actually was not.")
+	}
+	if expected, actual := http.StatusOK, recorder.Code; expected != actual {
+		t.Errorf("The actual HTTP status is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+		t.Logf("BODY: %s", recorder.Body)
+	}
+}
+
+func TestMiddleware_Wrap_multiple(t *testing.T) {
+
+	var middleware = xrpc.Middleware{
+		Params: []xrpc.Param{
+			{Name: "uris", Multiple: true},
+		},
+	}
+
+	var actual []string
+	var handler http.Handler = middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, uri := range xrpc.URIsFromContext(r.Context(), "uris") {
+			actual = append(actual, uri.String())
+		}
+	}))
+
+	var query = url.Values{
+		"uris": []string{
+			"at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y",
+			"at://reiver.bsky.social/app.bsky.feed.post/3l3qo2vuowo2b",
+		},
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/xrpc/app.bsky.feed.getPosts?"+query.Encode(), nil))
+
+	if expected := query["uris"]; strings.Join(expected, " ") != strings.Join(actual, " ") {
+		t.Errorf("The actual AT-URIs are not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+}
+
+func TestMiddleware_Wrap_fail(t *testing.T) {
+
+	denied, err := aturi.NewDIDSet("did:plc:ewvi7nxzyoun6zhxrhs64oiz")
+	if nil != err {
+		t.Fatalf("Did not expect an error but actually got one: (%T) %s", err, err)
+	}
+
+	tests := []struct{
+		Middleware xrpc.Middleware
+		Query string
+		ExpectedMessage string
+	}{
+		{
+			Middleware: xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}},
+			Query: "",
+			ExpectedMessage: `Error: Params must have the property "uri"`,
+		},
+		{
+			Middleware: xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}},
+			Query: "uri=at://example.com&uri=at://example.com",
+			ExpectedMessage: `Error: Params property "uri" may only be given once`,
+		},
+		{
+			Middleware: xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}},
+			Query: "uri=" + url.QueryEscape("https://example.com/"),
+			ExpectedMessage: `Error: Params property "uri" is not a valid at-uri (scheme): `,
+		},
+		{
+			Middleware: xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}, Parser: &aturi.Parser{Strict: true}},
+			Query: "uri=" + url.QueryEscape("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/"),
+			ExpectedMessage: `Error: Params property "uri" is not a valid at-uri (path): `,
+		},
+		{
+			Middleware: xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}, Parser: &aturi.Parser{DisallowQuery: true}},
+			Query: "uri=" + url.QueryEscape("at://did:plc:scewmn2pl3oz36mxme2b6czz/app.bsky.feed.post/3jui7kd54zh2y?once=1"),
+			ExpectedMessage: `Error: Params property "uri" is not a valid at-uri (query): `,
+		},
+		{
+			Middleware: xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}, Parser: &aturi.Parser{Authorities: aturi.AuthorityDID, Policy: &aturi.Policy{DenyDIDs: denied}}},
+			Query: "uri=" + url.QueryEscape("at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3jui7kd54zh2y"),
+			ExpectedMessage: `Error: Params property "uri" is not a valid at-uri (policy): `,
+		},
+		{
+			Middleware: xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}, Parser: &aturi.Parser{Policy: &aturi.Policy{DenyDIDs: denied}}},
+			Query: "uri=" + url.QueryEscape("at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3jui7kd54zh2y"),
+			ExpectedMessage: `Error: Params property "uri" is not a valid at-uri (policy): `,
+		},
+		{
+			Middleware: xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}, Parser: &aturi.Parser{Policy: &aturi.Policy{AllowCollectionPrefixes: []string{"app.bsky.feed"}, DenyDIDs: denied}}},
+			Query: "uri=" + url.QueryEscape("at://did:plc:ewvi7nxzyoun6zhxrhs64oiz/app.bsky.feed.post/3jui7kd54zh2y"),
+			ExpectedMessage: `Error: Params property "uri" is not a valid at-uri (policy): `,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var handler http.Handler = test.Middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("For test #%d, did not expect the wrapped handler to be called but it actually was.", testNumber)
+		}))
+
+		var recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/xrpc/app.bsky.feed.getPostThread?"+test.Query, nil))
+
+		if expected, actual := http.StatusBadRequest, recorder.Code; expected != actual {
+			t.Errorf("For test #%d, the actual HTTP status is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+
+		if expected, actual := "application/json; charset=utf-8", recorder.Header().Get("Content-Type"); expected != actual {
+			t.Errorf("For test #%d, the actual Content-Type is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+
+		var body xrpc.ErrorBody
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			t.Logf("BODY: %s", recorder.Body)
+			continue
+		}
+
+		if expected, actual := "InvalidRequest", body.Error; expected != actual {
+			t.Errorf("For test #%d, the actual XRPC error is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+
+		if expected, actual := test.ExpectedMessage, body.Message; !strings.HasPrefix(actual, expected) {
+			t.Errorf("For test #%d, the actual XRPC error message is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q…", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestMiddleware_Wrap_nilHandler(t *testing.T) {
+
+	defer func() {
+		if nil == recover() {
+			t.Errorf("Expected Wrap to panic() for a nil handler but it actually did not.")
+		}
+	}()
+
+	xrpc.Middleware{Params: []xrpc.Param{{Name: "uri"}}}.Wrap(nil)
+}
+
+func TestMiddleware_Parse_paramError(t *testing.T) {
+
+	var middleware = xrpc.Middleware{
+		Params: []xrpc.Param{{Name: "uri"}},
+	}
+
+	_, err := middleware.Parse(httptest.NewRequest(http.MethodGet, "/?uri="+url.QueryEscape("at://example.com/foorBar"), nil))
+	if nil == err {
+		t.Fatalf("Expected an error but did not actually get one.")
+	}
+
+	var paramError *xrpc.ParamError
+	if !errors.As(err, &paramError) {
+		t.Fatalf("Expected the error to be an %T but it actually is not: (%T) %s", paramError, err, err)
+	}
+	if expected, actual := "uri", paramError.Name; expected != actual {
+		t.Errorf("The actual parameter name is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+
+	var aturiError *aturi.Error
+	if !errors.As(err, &aturiError) {
+		t.Fatalf("Expected the error to wrap an %T but it actually does not: (%T) %s", aturiError, err, err)
+	}
+	if expected, actual := aturi.ErrorKindCollection, aturiError.Kind; expected != actual {
+		t.Errorf("The actual error kind is not what was expected.")
+		t.Logf("EXPECTED: %q", expected)
+		t.Logf("ACTUAL:   %q", actual)
+	}
+}
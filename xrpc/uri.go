@@ -0,0 +1,16 @@
+package xrpc
+
+// URI is an AT-URI parameter that the [Middleware] parsed.
+type URI struct {
+	Raw        string // the AT-URI as it was in the request
+	Authority  string
+	Collection string
+	RKey       string
+	Query      string
+	Fragment   string
+}
+
+// String returns the AT-URI as it was in the request.
+func (receiver URI) String() string {
+	return receiver.Raw
+}
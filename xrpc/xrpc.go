@@ -0,0 +1,25 @@
+// Package xrpc has net/http middleware for XRPC endpoints that take AT-URI query parameters
+// (such as the "uri" parameter of "app.bsky.feed.getPostThread" and "app.bsky.feed.getLikes").
+//
+// For example:
+//
+//	var middleware = xrpc.Middleware{
+//		Params: []xrpc.Param{
+//			{Name: "uri"},
+//		},
+//		Parser: &aturi.Parser{
+//			Strict: true,
+//		},
+//	}
+//	
+//	http.Handle("/xrpc/app.bsky.feed.getLikes", middleware.Wrap(http.HandlerFunc(getLikes)))
+//	
+//	func getLikes(w http.ResponseWriter, r *http.Request) {
+//		uri, _ := xrpc.URIFromContext(r.Context(), "uri")
+//		
+//		// ...
+//	}
+//
+// A request with a missing or invalid AT-URI parameter gets an HTTP 400 response with an XRPC "InvalidRequest" error body,
+// and never gets to the wrapped handler.
+package xrpc